@@ -14,6 +14,8 @@ import (
 	"github.com/htm-project/neural-api/internal/api"
 	"github.com/htm-project/neural-api/internal/handlers"
 	"github.com/htm-project/neural-api/internal/infrastructure/config"
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
 	"github.com/htm-project/neural-api/internal/services"
 )
 
@@ -38,6 +40,8 @@ type Application struct {
 	config     *config.Config
 	server     *http.Server
 	router     *gin.Engine
+	sensors    *sensors.Registry
+	instances  map[string]sensors.SensorInterface
 	shutdownCh chan os.Signal
 }
 
@@ -53,6 +57,17 @@ func initializeApplication(cfg *config.Config) (*Application, error) {
 	// Initialize metrics collector (simplified implementation)
 	metricsCollector := &SimpleMetricsCollector{}
 
+	// Initialize sensor registry with all built-in encoders
+	sensorRegistry, err := encoders.NewDefaultRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("failed to register sensors: %w", err)
+	}
+
+	sensorInstances, err := loadSensorDefinitions(sensorRegistry, cfg.Sensors.DefinitionsPath)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize services
 	matrixProcessor := services.NewMatrixProcessor(metricsCollector)
 	validationService := services.NewValidationService(metricsCollector)
@@ -109,10 +124,33 @@ func initializeApplication(cfg *config.Config) (*Application, error) {
 		config:     cfg,
 		server:     server,
 		router:     router,
+		sensors:    sensorRegistry,
+		instances:  sensorInstances,
 		shutdownCh: shutdownCh,
 	}, nil
 }
 
+// loadSensorDefinitions creates the named sensor instances described in the
+// definitions file, if one is configured, reporting every invalid entry at once.
+func loadSensorDefinitions(registry *sensors.Registry, path string) (map[string]sensors.SensorInterface, error) {
+	if path == "" {
+		return map[string]sensors.SensorInterface{}, nil
+	}
+
+	definitions, err := sensors.LoadDefinitions(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sensor definitions: %w", err)
+	}
+
+	instances, err := registry.CreateDefined(definitions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sensors: %w", err)
+	}
+
+	log.Printf("Loaded %d sensor definitions from %s", len(instances), path)
+	return instances, nil
+}
+
 // Run starts the HTTP server and handles graceful shutdown.
 func (app *Application) Run() error {
 	// Start server in a goroutine
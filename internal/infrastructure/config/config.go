@@ -12,6 +12,7 @@ type Config struct {
 	API     APIConfig
 	Logging LoggingConfig
 	Metrics MetricsConfig
+	Sensors SensorsConfig
 }
 
 // ServerConfig contains HTTP server configuration
@@ -44,6 +45,11 @@ type MetricsConfig struct {
 	Path    string
 }
 
+// SensorsConfig contains sensor registry configuration
+type SensorsConfig struct {
+	DefinitionsPath string // JSON or YAML file of named sensor instances, optional
+}
+
 // Load reads configuration from environment variables with defaults
 func Load() *Config {
 	return &Config{
@@ -69,6 +75,9 @@ func Load() *Config {
 			Enabled: getBoolEnv("METRICS_ENABLED", true),
 			Path:    getEnv("METRICS_PATH", "/metrics"),
 		},
+		Sensors: SensorsConfig{
+			DefinitionsPath: getEnv("SENSOR_DEFINITIONS", ""),
+		},
 	}
 }
 
@@ -0,0 +1,103 @@
+package sensors
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BatchResult holds the outcome of encoding one batch item
+type BatchResult struct {
+	SDR SDR   // Encoded representation, nil if encoding failed
+	Err error // Per-item error; a failed item does not abort the batch
+}
+
+// BatchEncoder is implemented by sensors with a native batch path, for
+// example one that reuses scratch buffers between items
+type BatchEncoder interface {
+	// EncodeBatch encodes inputs in order, writing one result per input
+	// into results, which has the same length as inputs
+	EncodeBatch(inputs []interface{}, results []BatchResult)
+}
+
+// BatchOptions controls how EncodeBatch distributes work
+type BatchOptions struct {
+	Workers int           // Parallel sensor instances; values below 2 encode sequentially on the given sensor
+	Results []BatchResult // Optional buffer reused for results when its capacity suffices
+}
+
+// EncodeBatch encodes a slice of inputs (FR-008). Sensors implementing
+// BatchEncoder use their native path, other sensors fall back to calling
+// Encode per item. With more than one worker the inputs are split into
+// contiguous chunks, each encoded by its own Clone() of the sensor. Stateful
+// sensors always encode sequentially, because their encodings depend on the
+// inputs seen before.
+func EncodeBatch(sensor SensorInterface, inputs []interface{}, options BatchOptions) []BatchResult {
+	results := options.Results
+	if cap(results) >= len(inputs) {
+		results = results[:len(inputs)]
+		clear(results)
+	} else {
+		results = make([]BatchResult, len(inputs))
+	}
+
+	workers := min(options.Workers, len(inputs))
+	if workers < 2 || isStateful(sensor) {
+		encodeSequential(sensor, inputs, results)
+		return results
+	}
+
+	chunkSize := (len(inputs) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(inputs); start += chunkSize {
+		end := min(start+chunkSize, len(inputs))
+
+		// The caller's sensor takes the first chunk, every other chunk gets a clone
+		worker := sensor
+		if start > 0 {
+			worker = sensor.Clone()
+		}
+
+		wg.Add(1)
+		go func(worker SensorInterface, start, end int) {
+			defer wg.Done()
+			encodeSequential(worker, inputs[start:end], results[start:end])
+		}(worker, start, end)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// isStateful reports whether a sensor's encodings depend on previous inputs,
+// in which case clones would diverge from the original
+func isStateful(sensor SensorInterface) bool {
+	return sensor.Metadata().Stateful
+}
+
+// encodeSequential encodes inputs on a single sensor instance
+func encodeSequential(sensor SensorInterface, inputs []interface{}, results []BatchResult) {
+	if batch, ok := sensor.(BatchEncoder); ok {
+		batch.EncodeBatch(inputs, results)
+		return
+	}
+
+	for i, input := range inputs {
+		sdr, err := sensor.Encode(input)
+		results[i] = BatchResult{SDR: sdr, Err: err}
+	}
+}
+
+// EncodeBatch creates and configures a sensor of the given type and encodes
+// the inputs with it; configuration errors abort the batch
+func (r *Registry) EncodeBatch(sensorType string, config SensorConfig, inputs []interface{}, options BatchOptions) ([]BatchResult, error) {
+	sensor, err := r.Create(sensorType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sensor.Configure(config); err != nil {
+		return nil, fmt.Errorf("failed to configure sensor '%s': %v", sensorType, err)
+	}
+
+	return EncodeBatch(sensor, inputs, options), nil
+}
@@ -163,6 +163,42 @@ func (c *SensorConfig) ValidateRange() error {
 	return nil
 }
 
+// ValidateRadius checks the optional "radius" parameter: it must be positive
+// and, for a bounded range, at most half the range span
+func (c *SensorConfig) ValidateRadius() error {
+	value, exists := c.CustomParams["radius"]
+	if !exists {
+		return nil
+	}
+
+	radius, ok := paramNumber(value)
+	if !ok {
+		return &ConfigurationError{
+			Parameter: "radius",
+			Value:     value,
+			Reason:    fmt.Sprintf("must be a number, got %T", value),
+		}
+	}
+
+	if radius <= 0.0 {
+		return &ConfigurationError{
+			Parameter: "radius",
+			Value:     radius,
+			Reason:    "must be positive",
+		}
+	}
+
+	if c.Range != nil && radius > (c.Range.Max-c.Range.Min)/2 {
+		return &ConfigurationError{
+			Parameter: "radius",
+			Value:     radius,
+			Reason:    fmt.Sprintf("must be at most half the range span (%.3f)", (c.Range.Max-c.Range.Min)/2),
+		}
+	}
+
+	return nil
+}
+
 // IsValid performs complete validation of the configuration
 func (c *SensorConfig) IsValid() error {
 	if err := c.ValidateSDRWidth(); err != nil {
@@ -181,6 +217,10 @@ func (c *SensorConfig) IsValid() error {
 		return err
 	}
 
+	if err := c.ValidateRadius(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,274 @@
+package sensors
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SensorDefinition is a named sensor instance described in a definitions file
+type SensorDefinition struct {
+	Name     string        // Unique instance name
+	Type     string        // Registry key, optionally pinned to a version such as "numeric@1"
+	Template string        // PredefinedConfigurations template the config started from, if any
+	Config   *SensorConfig // Template merged with the file's overrides
+	Source   string        // File the definition was read from
+	Line     int           // Line of the definition in Source
+}
+
+// DefinitionError reports an invalid entry of a definitions file
+type DefinitionError struct {
+	Source string // File name
+	Line   int    // Line of the offending entry or value
+	Sensor string // Sensor name, empty if unknown
+	Reason string
+}
+
+func (e *DefinitionError) Error() string {
+	location := fmt.Sprintf("%s:%d", e.Source, e.Line)
+	if e.Sensor != "" {
+		return location + ": sensor '" + e.Sensor + "': " + e.Reason
+	}
+	return location + ": " + e.Reason
+}
+
+// DefinitionErrors collects every invalid entry of a definitions file
+type DefinitionErrors []*DefinitionError
+
+func (e DefinitionErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d invalid sensor definition(s):\n%s", len(e), strings.Join(messages, "\n"))
+}
+
+// definitionKeys are the keys accepted in a sensor definition entry
+var definitionKeys = map[string]bool{
+	"name": true, "type": true, "template": true, "sdr_width": true,
+	"target_sparsity": true, "resolution": true, "range": true, "params": true,
+}
+
+// LoadDefinitions reads sensor definitions from a JSON or YAML file
+func LoadDefinitions(path string) ([]SensorDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sensor definitions: %v", err)
+	}
+
+	return ParseDefinitions(data, path)
+}
+
+// ParseDefinitions parses sensor definitions of the form
+//
+//	sensors:
+//	  - name: temperature
+//	    type: numeric
+//	    template: small
+//	    resolution: 0.5
+//	    range: {min: -20, max: 50}
+//	    params: {silent_failure: false}
+//
+// JSON documents with the same structure are accepted as well. Every entry
+// is validated with SensorConfig.IsValid; all invalid entries are reported
+// together as DefinitionErrors, alongside the definitions that are valid.
+func ParseDefinitions(data []byte, source string) ([]SensorDefinition, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, &DefinitionError{Source: source, Line: 1, Reason: err.Error()}
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	document := root.Content[0]
+	if document.Kind != yaml.MappingNode {
+		return nil, &DefinitionError{Source: source, Line: document.Line, Reason: "expected a mapping with a 'sensors' list"}
+	}
+
+	var entries *yaml.Node
+	var errs DefinitionErrors
+	for i := 0; i < len(document.Content); i += 2 {
+		key, value := document.Content[i], document.Content[i+1]
+		if key.Value != "sensors" {
+			errs = append(errs, &DefinitionError{Source: source, Line: key.Line, Reason: fmt.Sprintf("unknown key '%s'", key.Value)})
+			continue
+		}
+		if value.Kind != yaml.SequenceNode {
+			errs = append(errs, &DefinitionError{Source: source, Line: value.Line, Reason: "'sensors' must be a list"})
+			continue
+		}
+		entries = value
+	}
+
+	var definitions []SensorDefinition
+	if entries != nil {
+		firstLine := make(map[string]int)
+		for _, entry := range entries.Content {
+			definition, entryErrs := parseDefinition(entry, source)
+			if len(entryErrs) > 0 {
+				errs = append(errs, entryErrs...)
+				continue
+			}
+
+			if line, duplicate := firstLine[definition.Name]; duplicate {
+				errs = append(errs, &DefinitionError{
+					Source: source,
+					Line:   entry.Line,
+					Sensor: definition.Name,
+					Reason: fmt.Sprintf("duplicate name, first defined on line %d", line),
+				})
+				continue
+			}
+			firstLine[definition.Name] = entry.Line
+			definitions = append(definitions, *definition)
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+		return definitions, errs
+	}
+	return definitions, nil
+}
+
+// parseDefinition decodes and validates a single sensor entry
+func parseDefinition(entry *yaml.Node, source string) (*SensorDefinition, DefinitionErrors) {
+	if entry.Kind != yaml.MappingNode {
+		return nil, DefinitionErrors{{Source: source, Line: entry.Line, Reason: "sensor definition must be a mapping"}}
+	}
+
+	definition := &SensorDefinition{Source: source, Line: entry.Line}
+	values := make(map[string]*yaml.Node)
+	var errs DefinitionErrors
+	for i := 0; i < len(entry.Content); i += 2 {
+		key, value := entry.Content[i], entry.Content[i+1]
+		if !definitionKeys[key.Value] {
+			errs = append(errs, &DefinitionError{Source: source, Line: key.Line, Reason: fmt.Sprintf("unknown key '%s'", key.Value)})
+			continue
+		}
+		values[key.Value] = value
+	}
+
+	// report attaches the sensor name once it is known
+	report := func(node *yaml.Node, reason string) {
+		errs = append(errs, &DefinitionError{Source: source, Line: node.Line, Sensor: definition.Name, Reason: reason})
+	}
+	decode := func(key string, target interface{}) bool {
+		node, exists := values[key]
+		if !exists {
+			return false
+		}
+		if err := node.Decode(target); err != nil {
+			report(node, fmt.Sprintf("invalid %s: %v", key, err))
+			return false
+		}
+		return true
+	}
+
+	decode("name", &definition.Name)
+	if definition.Name == "" {
+		report(entry, "name is required")
+	}
+	for i := range errs {
+		errs[i].Sensor = definition.Name
+	}
+
+	decode("type", &definition.Type)
+	if definition.Type == "" {
+		report(entry, "type is required")
+	}
+
+	config := NewSensorConfig()
+	if decode("template", &definition.Template) {
+		template, err := GetTemplate(definition.Template)
+		if err != nil {
+			report(values["template"], err.Error())
+		} else {
+			config = template
+		}
+	}
+
+	var width int
+	if decode("sdr_width", &width) {
+		config.SDRWidth = width
+	}
+
+	var sparsity float64
+	if decode("target_sparsity", &sparsity) {
+		config.TargetSparsity = sparsity
+	}
+
+	var resolution float64
+	if decode("resolution", &resolution) {
+		config.Resolution = resolution
+	}
+
+	var bounds struct {
+		Min *float64 `yaml:"min"`
+		Max *float64 `yaml:"max"`
+	}
+	if decode("range", &bounds) {
+		if config.Range == nil {
+			config.Range = &Range{}
+		}
+		if bounds.Min != nil {
+			config.Range.Min = *bounds.Min
+		}
+		if bounds.Max != nil {
+			config.Range.Max = *bounds.Max
+		}
+	}
+
+	var params map[string]interface{}
+	if decode("params", &params) {
+		for key, value := range params {
+			config.SetParam(key, value)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if err := config.IsValid(); err != nil {
+		report(entry, err.Error())
+		return nil, errs
+	}
+
+	definition.Config = config
+	return definition, nil
+}
+
+// CreateDefined creates and configures a sensor instance for every
+// definition, keyed by name. Unknown types and configuration failures are
+// reported together as DefinitionErrors, alongside the sensors that could
+// be created.
+func (r *Registry) CreateDefined(definitions []SensorDefinition) (map[string]SensorInterface, error) {
+	instances := make(map[string]SensorInterface, len(definitions))
+	var errs DefinitionErrors
+	for _, definition := range definitions {
+		sensor, err := r.Create(definition.Type)
+		if err == nil {
+			err = sensor.Configure(*definition.Config)
+		}
+		if err != nil {
+			errs = append(errs, &DefinitionError{
+				Source: definition.Source,
+				Line:   definition.Line,
+				Sensor: definition.Name,
+				Reason: err.Error(),
+			})
+			continue
+		}
+		instances[definition.Name] = sensor
+	}
+
+	if len(errs) > 0 {
+		return instances, errs
+	}
+	return instances, nil
+}
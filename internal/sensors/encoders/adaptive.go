@@ -0,0 +1,238 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// Re-bucketing policies of the adaptive numeric encoder
+const (
+	rebucketExpand = "expand" // Widen the range to include values outside it
+	rebucketClip   = "clip"   // Keep the learned range and clip values outside it
+)
+
+// AdaptiveNumericSensor encodes numeric streams whose range is not known
+// upfront. The range is learned from the observed values: during warm-up it
+// tracks the exact minimum and maximum, afterwards values outside it either
+// widen the range by a safety margin ("expand") or are clipped ("clip").
+// The bucket count is fixed, so every re-bucketing changes the encoding of
+// previously seen values; pick the clip policy once the range is stable.
+type AdaptiveNumericSensor struct {
+	baseSensor
+	buckets   int     // Number of distinguishable value buckets
+	positions int     // Number of possible start positions for the active block
+	warmup    int     // Observations before the range stops tracking exact extremes
+	policy    string  // Re-bucketing policy after warm-up
+	margin    float64 // Fraction of the span added when the range expands
+
+	mutex    *sync.Mutex // Guards the learned range across concurrent Encode calls
+	minValue float64     // Learned lower bound
+	maxValue float64     // Learned upper bound
+	samples  int         // Number of values observed
+	rebucket int         // Number of range changes after warm-up
+}
+
+// adaptiveParams lists the custom parameters of the adaptive numeric encoder
+var adaptiveParams = []sensors.ParamSpec{
+	sensors.IntParam("buckets", "Buckets across the learned range, at most the available positions").WithDefault(100).WithMin(2),
+	sensors.IntParam("warmup", "Observations collected before the range is fixed").WithDefault(20).WithMin(0),
+	sensors.StringParam("rebucket", "What happens to values outside the learned range").WithDefault(rebucketExpand).WithEnum(rebucketExpand, rebucketClip),
+	sensors.FloatParam("margin", "Fraction of the span added on both sides of the learned range").WithDefault(0.1).WithMin(0),
+	sensors.BoolParam("use_range", "Start from Range instead of the first observation").WithDefault(false),
+}
+
+// NewAdaptiveNumericSensor creates an unconfigured adaptive-range numeric encoder
+func NewAdaptiveNumericSensor() sensors.SensorInterface {
+	return &AdaptiveNumericSensor{
+		baseSensor: newBaseSensor("adaptive", adaptiveParams...),
+		mutex:      &sync.Mutex{},
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// CustomParams: buckets (int, default 100), warmup (int observations,
+// default 20), rebucket ("expand" or "clip", default "expand"), margin
+// (float64 fraction of the span, default 0.1), use_range (bool, start from
+// Range instead of the first observation), silent_failure (bool)
+func (s *AdaptiveNumericSensor) Configure(config sensors.SensorConfig) error {
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	positions := cfg.SDRWidth - activeBitsFor(cfg) + 1
+	buckets := cfg.GetIntParam("buckets", 100)
+	if buckets > positions {
+		return &sensors.ConfigurationError{
+			Parameter: "buckets",
+			Value:     buckets,
+			Reason:    fmt.Sprintf("must be at most the %d available positions", positions),
+		}
+	}
+
+	warmup := cfg.GetIntParam("warmup", 20)
+	policy := cfg.GetStringParam("rebucket", rebucketExpand)
+	margin := cfg.GetFloatParam("margin", 0.1)
+	useRange := cfg.GetBoolParam("use_range", false)
+	if useRange {
+		if cfg.Range == nil {
+			return &sensors.ConfigurationError{
+				Parameter: "range",
+				Value:     nil,
+				Reason:    "use_range requires a range",
+			}
+		}
+		if err := cfg.ValidateRange(); err != nil {
+			return err
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.applyConfig(cfg)
+	s.buckets = buckets
+	s.positions = positions
+	s.warmup = warmup
+	s.policy = policy
+	s.margin = margin
+	s.samples, s.rebucket = 0, 0
+	s.minValue, s.maxValue = math.Inf(1), math.Inf(-1)
+	if useRange {
+		s.minValue, s.maxValue = cfg.Range.Min, cfg.Range.Max
+	}
+	return nil
+}
+
+// Encode observes a numeric value, updates the learned range and converts
+// the value into an SDR
+func (s *AdaptiveNumericSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	value, err := toFloat64(input)
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	if !isFinite(value) {
+		return s.fail(input, "value must be finite")
+	}
+
+	s.mutex.Lock()
+	s.observe(value)
+	start := s.bucketStart(value)
+	s.mutex.Unlock()
+
+	activeBits := make([]int, s.activeBitsCount())
+	for i := range activeBits {
+		activeBits[i] = start + i
+	}
+	return s.newSDR(activeBits)
+}
+
+// observe updates the learned range with a value; callers hold the mutex
+func (s *AdaptiveNumericSensor) observe(value float64) {
+	s.samples++
+	if value >= s.minValue && value <= s.maxValue {
+		return
+	}
+
+	if s.samples <= s.warmup {
+		s.minValue = math.Min(s.minValue, value)
+		s.maxValue = math.Max(s.maxValue, value)
+		return
+	}
+
+	if s.policy == rebucketClip && s.minValue <= s.maxValue {
+		return
+	}
+
+	low, high := math.Min(s.minValue, value), math.Max(s.maxValue, value)
+	headroom := (high - low) * s.margin
+	if value < s.minValue {
+		low -= headroom
+	}
+	if value > s.maxValue {
+		high += headroom
+	}
+	if s.minValue <= s.maxValue {
+		s.rebucket++
+	}
+	s.minValue, s.maxValue = low, high
+}
+
+// bucketStart returns the first active bit for a value under the learned
+// range; values outside the range are clipped and a single-point range maps
+// to the middle bucket. Callers hold the mutex.
+func (s *AdaptiveNumericSensor) bucketStart(value float64) int {
+	bucket := (s.buckets - 1) / 2
+	if span := s.maxValue - s.minValue; span > 0 {
+		clipped := math.Max(s.minValue, math.Min(s.maxValue, value))
+		bucket = int(math.Round((clipped - s.minValue) / span * float64(s.buckets-1)))
+	}
+	return int(math.Round(float64(bucket) * float64(s.positions-1) / float64(s.buckets-1)))
+}
+
+// LearnedRange returns the current range and whether any value has been observed
+func (s *AdaptiveNumericSensor) LearnedRange() (sensors.Range, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return sensors.Range{Min: s.minValue, Max: s.maxValue}, s.minValue <= s.maxValue
+}
+
+// Validate checks if sensor configuration is valid
+func (s *AdaptiveNumericSensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if s.buckets < 2 || s.buckets > s.positions {
+		return &sensors.ValidationError{
+			Component: "adaptive",
+			Reason:    fmt.Sprintf("invalid bucket layout: %d buckets over %d positions", s.buckets, s.positions),
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *AdaptiveNumericSensor) Metadata() sensors.SensorMetadata {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.metadata(map[string]interface{}{
+		"min_value":   s.minValue,
+		"max_value":   s.maxValue,
+		"buckets":     s.buckets,
+		"warmup":      s.warmup,
+		"warmed_up":   s.samples >= s.warmup,
+		"samples":     s.samples,
+		"rebucket":    s.policy,
+		"rebucketed":  s.rebucket,
+		"margin":      s.margin,
+		"stateful":    true,
+		"input_types": []string{"float64", "float32", "int", "int64", "int32", "uint"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration and learned range
+func (s *AdaptiveNumericSensor) Clone() sensors.SensorInterface {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	clone := *s
+	clone.baseSensor = s.clone()
+	clone.mutex = &sync.Mutex{}
+	return &clone
+}
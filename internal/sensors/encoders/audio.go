@@ -0,0 +1,325 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// Band spacing of the audio encoder
+const (
+	audioScaleLog    = "log"    // Logarithmically spaced bands, close to pitch perception
+	audioScaleLinear = "linear" // Equally wide bands
+)
+
+// maxAudioWindow bounds the number of samples per encoded window
+const maxAudioWindow = 1 << 16
+
+// audioBand is one frequency band of the audio encoding
+type audioBand struct {
+	low, high  float64 // Band edges in Hz
+	offset     int     // First SDR bit of the band segment
+	width      int     // Number of SDR bits in the segment
+	activeBits int     // Active bits of the band level block
+}
+
+// AudioSensor encodes windows of PCM samples by their frequency content.
+// A Hann-windowed FFT is summed into frequency bands, and every band owns a
+// segment of the SDR in frequency order. The band level in dB relative to
+// full scale positions a contiguous block of active bits in its segment, so
+// similar spectra share bits band by band and neighbouring levels overlap.
+type AudioSensor struct {
+	baseSensor
+	sampleRate float64     // Samples per second
+	floorDB    float64     // Level mapped to the bottom of every segment
+	hann       bool        // Apply a Hann window before the FFT
+	bands      []audioBand // Frequency bands in SDR layout order
+}
+
+// audioParams lists the custom parameters of the audio spectrum encoder
+var audioParams = []sensors.ParamSpec{
+	sensors.IntParam("sample_rate", "Sample rate of the PCM input in Hz").WithDefault(16000).WithMin(1),
+	sensors.IntParam("bands", "Frequency bands, at most the active bit count").WithDefault(16).WithMin(1),
+	sensors.FloatParam("min_frequency", "Lower edge of the lowest band in Hz, positive").WithDefault(50.0),
+	sensors.FloatParam("max_frequency", "Upper edge of the highest band in Hz; defaults to the Nyquist frequency"),
+	sensors.StringParam("band_scale", "Spacing of the band edges").WithDefault(audioScaleLog).WithEnum(audioScaleLog, audioScaleLinear),
+	sensors.FloatParam("floor_db", "Band level in dB treated as silence, negative").WithDefault(-80.0),
+	sensors.StringParam("window", "Window applied before the FFT").WithDefault("hann").WithEnum("hann", "none"),
+}
+
+// NewAudioSensor creates an unconfigured audio spectrum encoder
+func NewAudioSensor() sensors.SensorInterface {
+	return &AudioSensor{
+		baseSensor: newBaseSensor("audio", audioParams...),
+		sampleRate: 16000,
+		floorDB:    -80,
+		hann:       true,
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// CustomParams: sample_rate (int Hz, default 16000), bands (int, default 16),
+// min_frequency (float64 Hz, default 50), max_frequency (float64 Hz, default
+// Nyquist), band_scale ("log" or "linear", default "log"), floor_db (float64,
+// default -80), window ("hann" or "none", default "hann"), silent_failure (bool)
+func (s *AudioSensor) Configure(config sensors.SensorConfig) error {
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	sampleRate := float64(cfg.GetIntParam("sample_rate", 16000))
+	minFrequency := cfg.GetFloatParam("min_frequency", 50)
+	maxFrequency := cfg.GetFloatParam("max_frequency", sampleRate/2)
+	if minFrequency <= 0 || maxFrequency <= minFrequency || maxFrequency > sampleRate/2 {
+		return &sensors.ConfigurationError{
+			Parameter: "min_frequency",
+			Value:     fmt.Sprintf("[%g, %g]", minFrequency, maxFrequency),
+			Reason:    fmt.Sprintf("frequency band must satisfy 0 < min < max <= Nyquist (%g)", sampleRate/2),
+		}
+	}
+
+	floorDB := cfg.GetFloatParam("floor_db", -80)
+	if floorDB >= 0 {
+		return &sensors.ConfigurationError{
+			Parameter: "floor_db",
+			Value:     floorDB,
+			Reason:    "must be negative",
+		}
+	}
+
+	window := cfg.GetStringParam("window", "hann")
+	scale := cfg.GetStringParam("band_scale", audioScaleLog)
+
+	bandCount := cfg.GetIntParam("bands", 16)
+	totalActive := activeBitsFor(cfg)
+	if bandCount > totalActive {
+		return &sensors.ConfigurationError{
+			Parameter: "bands",
+			Value:     bandCount,
+			Reason:    fmt.Sprintf("must be at most the %d active bits", totalActive),
+		}
+	}
+
+	bands := make([]audioBand, bandCount)
+	for i := range bands {
+		var low, high float64
+		if scale == audioScaleLog {
+			ratio := maxFrequency / minFrequency
+			low = minFrequency * math.Pow(ratio, float64(i)/float64(bandCount))
+			high = minFrequency * math.Pow(ratio, float64(i+1)/float64(bandCount))
+		} else {
+			step := (maxFrequency - minFrequency) / float64(bandCount)
+			low = minFrequency + float64(i)*step
+			high = low + step
+		}
+
+		// Segments and active bits are split with cumulative rounding so the
+		// totals match the configured width and sparsity exactly
+		offset := i * cfg.SDRWidth / bandCount
+		active := (i+1)*totalActive/bandCount - i*totalActive/bandCount
+		bands[i] = audioBand{
+			low:        low,
+			high:       high,
+			offset:     offset,
+			width:      (i+1)*cfg.SDRWidth/bandCount - offset,
+			activeBits: active,
+		}
+		if bands[i].activeBits*2 > bands[i].width {
+			return &sensors.ConfigurationError{
+				Parameter: "bands",
+				Value:     bandCount,
+				Reason:    fmt.Sprintf("band segment of %d bits cannot hold %d active bits", bands[i].width, bands[i].activeBits),
+			}
+		}
+	}
+
+	s.applyConfig(cfg)
+	s.sampleRate = sampleRate
+	s.floorDB = floorDB
+	s.hann = window == "hann"
+	s.bands = bands
+	return nil
+}
+
+// Encode converts a window of PCM samples ([]float32 or []float64 in
+// [-1, 1], or []int16) into an SDR
+func (s *AudioSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	var samples []float64
+	switch v := input.(type) {
+	case []float32:
+		samples = make([]float64, len(v))
+		for i, sample := range v {
+			samples[i] = float64(sample)
+		}
+	case []float64:
+		samples = v
+	case []int16:
+		samples = make([]float64, len(v))
+		for i, sample := range v {
+			samples[i] = float64(sample) / 32768
+		}
+	default:
+		return s.fail(input, fmt.Sprintf("unsupported audio input type %T", input))
+	}
+
+	// Both Hann weights of a 2-sample window are zero, leaving no signal
+	minSamples := 2
+	if s.hann {
+		minSamples = 3
+	}
+	if len(samples) < minSamples || len(samples) > maxAudioWindow {
+		return s.fail(input, fmt.Sprintf("window must hold between %d and %d samples, got %d", minSamples, maxAudioWindow, len(samples)))
+	}
+
+	for _, sample := range samples {
+		if !isFinite(sample) {
+			return s.fail(input, "samples must be finite")
+		}
+	}
+
+	activeBits := make([]int, 0, s.activeBitsCount())
+	for i, level := range s.bandLevels(samples) {
+		if math.IsNaN(level) {
+			return s.fail(input, "band level is not a number")
+		}
+
+		band := s.bands[i]
+		positions := band.width - band.activeBits
+		fraction := (math.Max(level, s.floorDB) - s.floorDB) / -s.floorDB
+		start := band.offset + int(math.Round(math.Min(fraction, 1)*float64(positions)))
+		for bit := 0; bit < band.activeBits; bit++ {
+			activeBits = append(activeBits, start+bit)
+		}
+	}
+
+	return s.newSDR(activeBits)
+}
+
+// bandLevels returns the level of every band in dB relative to a full-scale sine
+func (s *AudioSensor) bandLevels(samples []float64) []float64 {
+	size := 1
+	for size < len(samples) {
+		size <<= 1
+	}
+
+	spectrum := make([]complex128, size)
+	gain := 0.0
+	for i, sample := range samples {
+		weight := 1.0
+		if s.hann {
+			weight = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(len(samples)-1))
+		}
+		spectrum[i] = complex(sample*weight, 0)
+		gain += weight
+	}
+	fft(spectrum)
+
+	// A full-scale sine concentrates power 1 in its one-sided bin
+	power := make([]float64, size/2+1)
+	for k := range power {
+		magnitude := 2 * cmplx.Abs(spectrum[k]) / gain
+		power[k] = magnitude * magnitude
+	}
+
+	binWidth := s.sampleRate / float64(size)
+	levels := make([]float64, len(s.bands))
+	for i, band := range s.bands {
+		total := 0.0
+		first := int(math.Ceil(band.low / binWidth))
+		last := int(math.Ceil(band.high/binWidth)) - 1
+		if last < first {
+			// Band narrower than a bin: use the bin containing its centre
+			first = int(math.Round((band.low + band.high) / 2 / binWidth))
+			last = first
+		}
+		for k := first; k <= last && k < len(power); k++ {
+			total += power[k]
+		}
+		levels[i] = 10 * math.Log10(total+1e-300)
+	}
+	return levels
+}
+
+// fft computes the in-place radix-2 FFT of a power-of-two length signal
+func fft(values []complex128) {
+	n := len(values)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j |= bit
+		if i < j {
+			values[i], values[j] = values[j], values[i]
+		}
+	}
+
+	for length := 2; length <= n; length <<= 1 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(length)))
+		for start := 0; start < n; start += length {
+			twiddle := complex(1, 0)
+			for k := 0; k < length/2; k++ {
+				even := values[start+k]
+				odd := values[start+k+length/2] * twiddle
+				values[start+k] = even + odd
+				values[start+k+length/2] = even - odd
+				twiddle *= step
+			}
+		}
+	}
+}
+
+// Validate checks if sensor configuration is valid
+func (s *AudioSensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if len(s.bands) == 0 {
+		return &sensors.ValidationError{
+			Component: "audio",
+			Reason:    "no frequency bands configured",
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *AudioSensor) Metadata() sensors.SensorMetadata {
+	edges := make([]float64, 0, len(s.bands)+1)
+	for i, band := range s.bands {
+		if i == 0 {
+			edges = append(edges, band.low)
+		}
+		edges = append(edges, band.high)
+	}
+
+	return s.metadata(map[string]interface{}{
+		"sample_rate": s.sampleRate,
+		"bands":       len(s.bands),
+		"band_edges":  edges,
+		"floor_db":    s.floorDB,
+		"hann_window": s.hann,
+		"input_types": []string{"[]float32", "[]float64", "[]int16"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *AudioSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	clone.bands = append([]audioBand(nil), s.bands...)
+	return &clone
+}
@@ -0,0 +1,343 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/sdr"
+)
+
+// maxInputSize is the per-operation input limit shared by all built-in encoders (1MB)
+const maxInputSize = 1024 * 1024
+
+// silentFailureParam is accepted by every built-in encoder
+var silentFailureParam = sensors.BoolParam("silent_failure", "Return an empty SDR instead of an error for invalid input").WithDefault(true)
+
+// seedParam declares the "seed" parameter of the hashing encoders
+func seedParam(description string) sensors.ParamSpec {
+	return sensors.IntParam("seed", description).WithDefault(defaultSeed).WithMin(0)
+}
+
+// baseSensor holds the configuration state shared by the built-in encoders
+type baseSensor struct {
+	sensorType  string
+	version     string              // Version of the encoding algorithm
+	schema      sensors.ParamSchema // Custom parameters accepted by Configure
+	config      *sensors.SensorConfig
+	identity    sensors.SensorIdentity // What determines the encodings, set by Configure
+	fingerprint string                 // Content hash of identity, attached to every SDR
+	configured  bool
+	silentMode  bool
+}
+
+// newBaseSensor creates an unconfigured base with HTM-compliant default
+// configuration that accepts the given custom parameters and silent_failure
+func newBaseSensor(sensorType string, params ...sensors.ParamSpec) baseSensor {
+	return baseSensor{
+		sensorType: sensorType,
+		version:    BuiltinVersion,
+		schema:     append(append(sensors.ParamSchema(nil), params...), silentFailureParam),
+		config:     sensors.NewSensorConfig(),
+		silentMode: true,
+	}
+}
+
+// prepareConfig coerces the custom parameters to the sensor's schema,
+// validates the common configuration fields and returns a private copy
+func (b *baseSensor) prepareConfig(config sensors.SensorConfig) (*sensors.SensorConfig, error) {
+	cfg := config.Clone()
+
+	if err := b.schema.Apply(cfg); err != nil {
+		return nil, err
+	}
+
+	// Parameters must serialize for the configuration fingerprint
+	if _, err := cfg.Canonical(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.ValidateSDRWidth(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.ValidateSparsity(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// applyConfig stores a configuration previously returned by prepareConfig
+func (b *baseSensor) applyConfig(cfg *sensors.SensorConfig) {
+	b.config = cfg
+	b.silentMode = cfg.GetBoolParam("silent_failure", true)
+	b.configured = true
+	b.identify(nil)
+}
+
+// identify records the sensor identity and its fingerprint; components
+// carries state outside the configuration such as child sensors
+func (b *baseSensor) identify(components map[string]interface{}) {
+	cfg := b.config.Clone()
+	b.schema.FillDefaults(cfg)
+	b.identity = sensors.SensorIdentity{
+		Type:       b.sensorType,
+		Version:    b.version,
+		Config:     cfg,
+		Components: components,
+	}
+
+	// prepareConfig has verified that the configuration serializes, and
+	// schema defaults and components are plain values
+	b.fingerprint, _ = b.identity.Fingerprint()
+}
+
+// SetVersion sets the version reported in metadata and identity; Registry.Create
+// calls it with the version the factory was registered under
+func (b *baseSensor) SetVersion(version string) {
+	b.version = version
+}
+
+// Identity returns the sensor identity as of the last successful Configure
+func (b *baseSensor) Identity() sensors.SensorIdentity {
+	identity := b.identity
+	if identity.Config != nil {
+		identity.Config = identity.Config.Clone()
+	}
+	return identity
+}
+
+// validate checks that the sensor has been configured with a valid configuration
+func (b *baseSensor) validate() error {
+	if !b.configured {
+		return &sensors.ValidationError{
+			Component: b.sensorType,
+			Reason:    "sensor is not configured",
+		}
+	}
+
+	if err := b.config.ValidateSDRWidth(); err != nil {
+		return err
+	}
+
+	return b.config.ValidateSparsity()
+}
+
+// notConfigured returns the error reported when Encode is called before Configure
+func (b *baseSensor) notConfigured(input interface{}) error {
+	return &sensors.EncodingError{
+		SensorType: b.sensorType,
+		Input:      input,
+		Reason:     "sensor must be configured before encoding",
+	}
+}
+
+// checkInput validates input presence and the 1MB size limit
+func (b *baseSensor) checkInput(input interface{}) error {
+	if input == nil {
+		return &sensors.ValidationError{
+			Component: "input",
+			Reason:    "input cannot be nil",
+		}
+	}
+
+	return sensors.NewInputSizeValidator().ValidateInputSize(input)
+}
+
+// fail returns an empty SDR in silent failure mode, otherwise an EncodingError
+func (b *baseSensor) fail(input interface{}, reason string) (sensors.SDR, error) {
+	if b.silentMode {
+		return b.emptySDR()
+	}
+
+	return nil, &sensors.EncodingError{
+		SensorType: b.sensorType,
+		Input:      input,
+		Reason:     reason,
+	}
+}
+
+// emptySDR creates an empty SDR of the configured width
+func (b *baseSensor) emptySDR() (sensors.SDR, error) {
+	empty, err := sdr.NewEmptySDR(b.config.SDRWidth)
+	if err != nil {
+		return nil, err
+	}
+	return sensors.NewFingerprintedSDR(empty, b.fingerprint), nil
+}
+
+// newSDR wraps the given active bits into a public SDR of the configured width
+func (b *baseSensor) newSDR(activeBits []int) (sensors.SDR, error) {
+	internal, err := sdr.NewSDR(b.config.SDRWidth, activeBits)
+	if err != nil {
+		return nil, err
+	}
+	return sensors.NewFingerprintedSDR(internal, b.fingerprint), nil
+}
+
+// activeBitsCount returns the number of active bits implied by the configuration
+func (b *baseSensor) activeBitsCount() int {
+	return activeBitsFor(b.config)
+}
+
+// metadata builds sensor metadata with the given type-specific capabilities
+func (b *baseSensor) metadata(capabilities map[string]interface{}) sensors.SensorMetadata {
+	if capabilities == nil {
+		capabilities = make(map[string]interface{})
+	}
+	capabilities["silent_failure"] = b.silentMode
+	capabilities["configured"] = b.configured
+	stateful, _ := capabilities["stateful"].(bool)
+
+	return sensors.SensorMetadata{
+		Type:         b.sensorType,
+		Version:      b.version,
+		Fingerprint:  b.fingerprint,
+		Stateful:     stateful,
+		SDRWidth:     b.config.SDRWidth,
+		Sparsity:     b.config.TargetSparsity,
+		MaxInputSize: maxInputSize,
+		Parameters:   append(sensors.ParamSchema(nil), b.schema...),
+		Capabilities: capabilities,
+	}
+}
+
+// clone returns a copy of the base with an independent configuration
+func (b *baseSensor) clone() baseSensor {
+	return baseSensor{
+		sensorType:  b.sensorType,
+		version:     b.version,
+		schema:      b.schema,
+		config:      b.config.Clone(),
+		identity:    b.identity,
+		fingerprint: b.fingerprint,
+		configured:  b.configured,
+		silentMode:  b.silentMode,
+	}
+}
+
+// toFloat64 converts any Go numeric type to float64
+func toFloat64(input interface{}) (float64, error) {
+	switch v := input.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint8:
+		return float64(v), nil
+	case uint16:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	default:
+		return 0, fmt.Errorf("unsupported numeric input type %T", input)
+	}
+}
+
+// isFinite reports whether the value is neither NaN nor infinite
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// activeBitsFor returns the number of active bits for a configuration, at least one
+func activeBitsFor(cfg *sensors.SensorConfig) int {
+	count := cfg.CalculateActiveBitsCount()
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// stringListParam reads a list of strings from a custom parameter, accepting
+// both []string and JSON-decoded []interface{} values
+func stringListParam(cfg *sensors.SensorConfig, key string) ([]string, error) {
+	value, exists := cfg.GetParam(key)
+	if !exists || value == nil {
+		return nil, nil
+	}
+
+	return toStringList(key, value)
+}
+
+// toStringList converts a []string or []interface{} of strings into []string
+func toStringList(key string, value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return append(make([]string, 0, len(v)), v...), nil
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, &sensors.ConfigurationError{
+					Parameter: key,
+					Value:     value,
+					Reason:    fmt.Sprintf("expected a list of strings, found element of type %T", item),
+				}
+			}
+			result = append(result, str)
+		}
+		return result, nil
+	default:
+		return nil, &sensors.ConfigurationError{
+			Parameter: key,
+			Value:     value,
+			Reason:    fmt.Sprintf("expected a list of strings, got %T", value),
+		}
+	}
+}
+
+// floatListParam reads a list of numbers from a custom parameter, accepting
+// []float64, []int and JSON-decoded []interface{} values
+func floatListParam(cfg *sensors.SensorConfig, key string) ([]float64, error) {
+	value, exists := cfg.GetParam(key)
+	if !exists || value == nil {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case []float64:
+		return append(make([]float64, 0, len(v)), v...), nil
+	case []int:
+		result := make([]float64, len(v))
+		for i, item := range v {
+			result[i] = float64(item)
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]float64, 0, len(v))
+		for _, item := range v {
+			number, err := toFloat64(item)
+			if err != nil {
+				return nil, &sensors.ConfigurationError{
+					Parameter: key,
+					Value:     value,
+					Reason:    fmt.Sprintf("expected a list of numbers, found element of type %T", item),
+				}
+			}
+			result = append(result, number)
+		}
+		return result, nil
+	default:
+		return nil, &sensors.ConfigurationError{
+			Parameter: key,
+			Value:     value,
+			Reason:    fmt.Sprintf("expected a list of numbers, got %T", value),
+		}
+	}
+}
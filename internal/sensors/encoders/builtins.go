@@ -0,0 +1,82 @@
+package encoders
+
+import (
+	"fmt"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// BuiltinVersion is the version tag of the encoding algorithms shipped in
+// this package; it changes whenever an encoder's output for a given
+// configuration and input changes
+const BuiltinVersion = "1.0.0"
+
+// Builtin describes a shipped encoder that can be created without further wiring
+type Builtin struct {
+	Type    string                // Registered sensor type
+	Version string                // Version tag of the encoding algorithm
+	Factory sensors.SensorFactory // Creates an unconfigured sensor
+}
+
+// Builtins returns every standalone encoder shipped in this package. Layout
+// encoders (composite, struct, delta, json) need a field schema and are
+// registered through their own factories instead. When an encoder's
+// algorithm changes, its previous implementation stays listed under the old
+// version so models pinned to it keep encoding identically.
+func Builtins() []Builtin {
+	factories := []struct {
+		sensorType string
+		factory    sensors.SensorFactory
+	}{
+		{"numeric", NewNumericSensor},
+		{"categorical", NewCategoricalSensor},
+		{"text", NewTextSensor},
+		{"spatial", NewSpatialSensor},
+		{"datetime", NewDateTimeSensor},
+		{"geospatial", NewGeospatialSensor},
+		{"rdse", NewRDSESensor},
+		{"log", NewLogNumericSensor},
+		{"adaptive", NewAdaptiveNumericSensor},
+		{"periodic", NewPeriodicSensor},
+		{"embedding", NewEmbeddingSensor},
+		{"audio", NewAudioSensor},
+		{"gridcell", NewGridCellSensor},
+		{"hierarchical", NewHierarchicalSensor},
+		{"passthrough", NewPassthroughSensor},
+	}
+
+	builtins := make([]Builtin, len(factories))
+	for i, entry := range factories {
+		builtins[i] = Builtin{Type: entry.sensorType, Version: BuiltinVersion, Factory: entry.factory}
+	}
+	return builtins
+}
+
+// RegisterBuiltins registers every built-in encoder with its version tag.
+// If any type or version is already registered, the built-ins registered so
+// far are removed again and the registry is left as it was.
+func RegisterBuiltins(registry *sensors.Registry) error {
+	if registry == nil {
+		return fmt.Errorf("registry cannot be nil")
+	}
+
+	builtins := Builtins()
+	for i, builtin := range builtins {
+		if err := registry.RegisterVersion(builtin.Type, builtin.Version, builtin.Factory); err != nil {
+			for _, added := range builtins[:i] {
+				registry.Unregister(sensors.SensorKey(added.Type, added.Version))
+			}
+			return fmt.Errorf("failed to register built-in sensor '%s': %v", builtin.Type, err)
+		}
+	}
+	return nil
+}
+
+// NewDefaultRegistry creates a registry populated with every built-in encoder
+func NewDefaultRegistry() (*sensors.Registry, error) {
+	registry := sensors.NewRegistry()
+	if err := RegisterBuiltins(registry); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
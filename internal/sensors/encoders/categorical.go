@@ -0,0 +1,328 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// CategoricalSensor encodes discrete string categories into SDRs.
+// With a fixed vocabulary ("categories" parameter) every category owns a
+// dedicated, non-overlapping block of bits and unknown categories are rejected.
+// Without one the vocabulary is open and bits are chosen by hashing the
+// category, which keeps unrelated categories at chance-level overlap.
+// Categories listed together in a similarity group share a configurable
+// fraction of their active bits.
+type CategoricalSensor struct {
+	baseSensor
+	seed         uint64         // Seed for open vocabulary hashing
+	categories   map[string]int // Fixed vocabulary index, nil for open vocabulary
+	groupOf      map[string]string
+	groupIndex   map[string]int // Position of each group in the fixed layout
+	groupOverlap float64        // Fraction of active bits shared within a group
+}
+
+// categoricalParams lists the custom parameters of the categorical encoder
+var categoricalParams = []sensors.ParamSpec{
+	sensors.StringListParam("categories", "Fixed vocabulary; unknown categories are rejected. Open vocabulary when absent"),
+	sensors.ObjectParam("groups", "Map of group name to the categories that share bits"),
+	sensors.FloatParam("group_overlap", "Fraction of active bits shared within a group, between 0 and 1 (exclusive)").WithDefault(0.5),
+	seedParam("Seed for open vocabulary hashing"),
+}
+
+// NewCategoricalSensor creates an unconfigured categorical encoder
+func NewCategoricalSensor() sensors.SensorInterface {
+	return &CategoricalSensor{
+		baseSensor: newBaseSensor("categorical", categoricalParams...),
+		seed:       defaultSeed,
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// CustomParams: categories ([]string), groups (map of group name to []string),
+// group_overlap (float64, default 0.5), seed (int), silent_failure (bool)
+func (s *CategoricalSensor) Configure(config sensors.SensorConfig) error {
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	vocabulary, err := stringListParam(cfg, "categories")
+	if err != nil {
+		return err
+	}
+
+	var categories map[string]int
+	if vocabulary != nil {
+		if len(vocabulary) == 0 {
+			return &sensors.ConfigurationError{
+				Parameter: "categories",
+				Value:     vocabulary,
+				Reason:    "fixed vocabulary must not be empty",
+			}
+		}
+
+		categories = make(map[string]int, len(vocabulary))
+		for i, category := range vocabulary {
+			if _, exists := categories[category]; exists {
+				return &sensors.ConfigurationError{
+					Parameter: "categories",
+					Value:     category,
+					Reason:    "categories must be unique",
+				}
+			}
+			categories[category] = i
+		}
+	}
+
+	groupOf, groupNames, err := parseCategoryGroups(cfg, categories)
+	if err != nil {
+		return err
+	}
+
+	groupOverlap := cfg.GetFloatParam("group_overlap", 0.5)
+	if groupOverlap <= 0 || groupOverlap >= 1 {
+		return &sensors.ConfigurationError{
+			Parameter: "group_overlap",
+			Value:     groupOverlap,
+			Reason:    "must be between 0 and 1 (exclusive)",
+		}
+	}
+
+	activeBits := activeBitsFor(cfg)
+	if categories != nil {
+		required := len(categories)*activeBits + len(groupNames)*sharedBitsCount(activeBits, groupOverlap)
+		if required > cfg.SDRWidth {
+			return &sensors.ConfigurationError{
+				Parameter: "categories",
+				Value:     len(categories),
+				Reason: fmt.Sprintf("fixed vocabulary needs %d bits for non-overlapping blocks but SDR width is %d",
+					required, cfg.SDRWidth),
+			}
+		}
+	}
+
+	seed := cfg.GetIntParam("seed", defaultSeed)
+
+	groupIndex := make(map[string]int, len(groupNames))
+	for i, name := range groupNames {
+		groupIndex[name] = i
+	}
+
+	s.applyConfig(cfg)
+	s.seed = uint64(seed)
+	s.categories = categories
+	s.groupOf = groupOf
+	s.groupIndex = groupIndex
+	s.groupOverlap = groupOverlap
+	return nil
+}
+
+// parseCategoryGroups reads the "groups" parameter into a category to group
+// mapping and the sorted list of group names
+func parseCategoryGroups(cfg *sensors.SensorConfig, categories map[string]int) (map[string]string, []string, error) {
+	value, exists := cfg.GetParam("groups")
+	if !exists || value == nil {
+		return map[string]string{}, nil, nil
+	}
+
+	var raw map[string]interface{}
+	switch v := value.(type) {
+	case map[string][]string:
+		raw = make(map[string]interface{}, len(v))
+		for name, members := range v {
+			raw[name] = members
+		}
+	case map[string]interface{}:
+		raw = v
+	default:
+		return nil, nil, &sensors.ConfigurationError{
+			Parameter: "groups",
+			Value:     value,
+			Reason:    fmt.Sprintf("expected a map of group name to category list, got %T", value),
+		}
+	}
+
+	groupOf := make(map[string]string)
+	names := make([]string, 0, len(raw))
+	for name, membersValue := range raw {
+		members, err := toStringList("groups."+name, membersValue)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, member := range members {
+			if other, exists := groupOf[member]; exists {
+				return nil, nil, &sensors.ConfigurationError{
+					Parameter: "groups",
+					Value:     member,
+					Reason:    fmt.Sprintf("category belongs to both group '%s' and '%s'", other, name),
+				}
+			}
+			if categories != nil {
+				if _, known := categories[member]; !known {
+					return nil, nil, &sensors.ConfigurationError{
+						Parameter: "groups",
+						Value:     member,
+						Reason:    "group member is not part of the fixed vocabulary",
+					}
+				}
+			}
+			groupOf[member] = name
+		}
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return groupOf, names, nil
+}
+
+// sharedBitsCount returns how many active bits group members have in common
+func sharedBitsCount(activeBits int, overlap float64) int {
+	return int(math.Round(float64(activeBits) * overlap))
+}
+
+// Encode converts a category string into an SDR
+func (s *CategoricalSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	var category string
+	switch v := input.(type) {
+	case string:
+		category = v
+	case []byte:
+		category = string(v)
+	default:
+		return s.fail(input, fmt.Sprintf("unsupported categorical input type %T", input))
+	}
+
+	if s.categories != nil {
+		index, known := s.categories[category]
+		if !known {
+			return s.fail(input, fmt.Sprintf("unknown category '%s'", category))
+		}
+		return s.newSDR(s.fixedBits(category, index))
+	}
+
+	return s.newSDR(s.hashedBits(category))
+}
+
+// fixedBits lays out a fixed-vocabulary category: its own block followed by
+// the block of its similarity group, if any
+func (s *CategoricalSensor) fixedBits(category string, index int) []int {
+	activeBits := s.activeBitsCount()
+	ownCount := activeBits
+
+	bits := make([]int, 0, activeBits)
+	if group, grouped := s.groupOf[category]; grouped {
+		shared := sharedBitsCount(activeBits, s.groupOverlap)
+		groupStart := len(s.categories)*activeBits + s.groupIndex[group]*shared
+		for i := 0; i < shared; i++ {
+			bits = append(bits, groupStart+i)
+		}
+		ownCount -= shared
+	}
+
+	ownStart := index * activeBits
+	for i := 0; i < ownCount; i++ {
+		bits = append(bits, ownStart+i)
+	}
+	return bits
+}
+
+// hashedBits selects open-vocabulary bits by hashing the category and, for
+// grouped categories, its group
+func (s *CategoricalSensor) hashedBits(category string) []int {
+	activeBits := s.activeBitsCount()
+	width := s.config.SDRWidth
+	own := hashBits(s.seed, hashString(s.seed, "category:"+category), activeBits, width)
+
+	group, grouped := s.groupOf[category]
+	if !grouped {
+		return own
+	}
+
+	shared := sharedBitsCount(activeBits, s.groupOverlap)
+	bits := hashBits(s.seed, hashString(s.seed, "group:"+group), shared, width)
+	used := make(map[int]struct{}, activeBits)
+	for _, bit := range bits {
+		used[bit] = struct{}{}
+	}
+	for _, bit := range own {
+		if len(bits) == activeBits {
+			break
+		}
+		if _, taken := used[bit]; !taken {
+			used[bit] = struct{}{}
+			bits = append(bits, bit)
+		}
+	}
+	return bits
+}
+
+// Decode ranks the categories of a fixed vocabulary by their overlap with an
+// SDR; open vocabularies cannot be enumerated and are not decodable
+func (s *CategoricalSensor) Decode(input sensors.SDR, maxCandidates int) ([]sensors.DecodeCandidate, error) {
+	active, err := s.decodeInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.categories == nil {
+		return nil, &sensors.DecodingError{
+			SensorType: s.sensorType,
+			Reason:     "open vocabulary categories cannot be decoded; configure a fixed vocabulary",
+		}
+	}
+
+	scores := make([]decodeScore, 0, len(s.categories))
+	for category, index := range s.categories {
+		bits := s.fixedBits(category, index)
+		scores = append(scores, decodeScore{
+			value:   category,
+			index:   index,
+			overlap: countActive(active, 0, bits),
+			total:   len(bits),
+		})
+	}
+
+	return rankCandidates(scores, maxCandidates, nil), nil
+}
+
+// Validate checks if sensor configuration is valid
+func (s *CategoricalSensor) Validate() error {
+	return s.validate()
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *CategoricalSensor) Metadata() sensors.SensorMetadata {
+	groups := make([]string, 0, len(s.groupIndex))
+	for name := range s.groupIndex {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+
+	return s.metadata(map[string]interface{}{
+		"open_vocabulary": s.categories == nil,
+		"vocabulary_size": len(s.categories),
+		"groups":          groups,
+		"group_overlap":   s.groupOverlap,
+		"seed":            s.seed,
+		"input_types":     []string{"string", "[]byte"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *CategoricalSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	return &clone
+}
@@ -0,0 +1,356 @@
+package encoders
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// CompositeField names a child sensor of a CompositeSensor
+type CompositeField struct {
+	Name   string               // Input field name (map key, struct field name or json tag)
+	Type   string               // Registry key of the child, optionally version-pinned
+	Config sensors.SensorConfig // Child sensor configuration
+}
+
+// CompositeSegment describes where a child sensor's bits sit in the composite SDR
+type CompositeSegment struct {
+	Name   string // Input field name
+	Type   string // Child sensor type
+	Offset int    // First bit of the segment
+	Width  int    // Number of bits in the segment
+}
+
+// compositeChild is a configured child sensor and its segment
+type compositeChild struct {
+	segment CompositeSegment
+	sensor  sensors.SensorInterface
+}
+
+// CompositeSensor encodes multi-field inputs (FR-014). Every field is
+// encoded by its own child sensor created from a registry, and the child
+// SDRs are concatenated in field order. The composite width is the sum of
+// the child widths, and the offset of each child is reported in the
+// "layout" capability so downstream code can attribute bits to fields.
+type CompositeSensor struct {
+	baseSensor
+	registry *sensors.Registry
+	fields   []CompositeField
+	children []compositeChild
+}
+
+// NewCompositeSensor creates an unconfigured composite whose children are
+// created from the given registry
+func NewCompositeSensor(registry *sensors.Registry, fields ...CompositeField) *CompositeSensor {
+	return &CompositeSensor{
+		baseSensor: newBaseSensor("composite"),
+		registry:   registry,
+		fields:     append([]CompositeField(nil), fields...),
+	}
+}
+
+// CompositeFactory returns a factory for registering a fixed field layout
+// as a sensor type of its own
+func CompositeFactory(registry *sensors.Registry, fields ...CompositeField) sensors.SensorFactory {
+	return func() sensors.SensorInterface {
+		return NewCompositeSensor(registry, fields...)
+	}
+}
+
+// AddField appends a child field; the composite must be configured again afterwards
+func (s *CompositeSensor) AddField(field CompositeField) {
+	s.fields = append(s.fields, field)
+	s.configured = false
+}
+
+// Configure creates and configures the child sensors
+// SDRWidth and TargetSparsity are derived from the children; CustomParams:
+// silent_failure (bool)
+func (s *CompositeSensor) Configure(config sensors.SensorConfig) error {
+	if s.registry == nil {
+		return &sensors.ConfigurationError{
+			Parameter: "registry",
+			Value:     nil,
+			Reason:    "composite sensor requires a registry to create child sensors",
+		}
+	}
+
+	if len(s.fields) == 0 {
+		return &sensors.ConfigurationError{
+			Parameter: "fields",
+			Value:     nil,
+			Reason:    "composite sensor requires at least one field",
+		}
+	}
+
+	children := make([]compositeChild, 0, len(s.fields))
+	seen := make(map[string]struct{}, len(s.fields))
+	offset, weightedSparsity := 0, 0.0
+	for _, field := range s.fields {
+		if field.Name == "" {
+			return &sensors.ConfigurationError{
+				Parameter: "fields",
+				Value:     field.Type,
+				Reason:    "field name cannot be empty",
+			}
+		}
+		if _, duplicate := seen[field.Name]; duplicate {
+			return &sensors.ConfigurationError{
+				Parameter: "fields",
+				Value:     field.Name,
+				Reason:    "duplicate field name",
+			}
+		}
+		seen[field.Name] = struct{}{}
+
+		child, err := s.registry.Create(field.Type)
+		if err != nil {
+			return &sensors.ConfigurationError{
+				Parameter: "fields." + field.Name,
+				Value:     field.Type,
+				Reason:    err.Error(),
+			}
+		}
+
+		// Children always report errors so that a failing field fails the
+		// whole composite according to the composite's own silent mode
+		childConfig := *field.Config.Clone()
+		childConfig.SetParam("silent_failure", false)
+		if err := child.Configure(childConfig); err != nil {
+			return &sensors.ConfigurationError{
+				Parameter: "fields." + field.Name,
+				Value:     field.Type,
+				Reason:    err.Error(),
+			}
+		}
+
+		childMetadata := child.Metadata()
+		width := childMetadata.SDRWidth
+		children = append(children, compositeChild{
+			segment: CompositeSegment{Name: field.Name, Type: field.Type, Offset: offset, Width: width},
+			sensor:  child,
+		})
+		offset += width
+		weightedSparsity += childMetadata.Sparsity * float64(width)
+	}
+
+	combined := config.Clone()
+	combined.SDRWidth = offset
+	combined.TargetSparsity = weightedSparsity / float64(offset)
+
+	cfg, err := s.prepareConfig(*combined)
+	if err != nil {
+		return err
+	}
+
+	s.applyConfig(cfg)
+	s.children = children
+	s.identifyChildren(nil)
+	return nil
+}
+
+// identifyChildren records the composite identity from its configuration,
+// the field layout and every child fingerprint; wrappers pass their own
+// state outside the configuration as extra components
+func (s *CompositeSensor) identifyChildren(extra map[string]interface{}) {
+	type fieldIdentity struct {
+		Name        string `json:"name"`
+		Fingerprint string `json:"fingerprint"`
+	}
+
+	fields := make([]fieldIdentity, len(s.children))
+	for i, child := range s.children {
+		fields[i] = fieldIdentity{Name: child.segment.Name, Fingerprint: child.sensor.Metadata().Fingerprint}
+	}
+
+	components := map[string]interface{}{"fields": fields}
+	for key, value := range extra {
+		components[key] = value
+	}
+	s.identify(components)
+}
+
+// Encode encodes each field of a map or struct input with its child sensor
+// and concatenates the results
+func (s *CompositeSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	lookup, err := fieldLookup(input)
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	return s.encodeFields(input, lookup)
+}
+
+// emptySegment is a field value that leaves the field's segment without
+// active bits instead of encoding it
+type emptySegment struct{}
+
+// encodeFields encodes every child from the values returned by lookup and
+// concatenates the results; input is only used for error reporting
+func (s *CompositeSensor) encodeFields(input interface{}, lookup func(name string) (interface{}, bool)) (sensors.SDR, error) {
+	activeBits, err := s.encodeChildren(lookup)
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+	return s.newSDR(activeBits)
+}
+
+// encodeChildren returns the concatenated active bits of every child
+// encoding of the values returned by lookup
+func (s *CompositeSensor) encodeChildren(lookup func(name string) (interface{}, bool)) ([]int, error) {
+	var activeBits []int
+	for _, child := range s.children {
+		value, ok := lookup(child.segment.Name)
+		if !ok {
+			return nil, fmt.Errorf("missing field %q", child.segment.Name)
+		}
+		if _, empty := value.(emptySegment); empty {
+			continue
+		}
+
+		encoded, err := child.sensor.Encode(value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %v", child.segment.Name, err)
+		}
+		if encoded.Width() != child.segment.Width {
+			return nil, fmt.Errorf("field %q produced width %d, expected %d", child.segment.Name, encoded.Width(), child.segment.Width)
+		}
+
+		for _, bit := range encoded.ActiveBits() {
+			activeBits = append(activeBits, child.segment.Offset+bit)
+		}
+	}
+
+	return activeBits, nil
+}
+
+// fieldLookup returns an accessor for named fields of a map or struct input
+func fieldLookup(input interface{}) (func(name string) (interface{}, bool), error) {
+	if values, ok := input.(map[string]interface{}); ok {
+		return func(name string) (interface{}, bool) {
+			value, exists := values[name]
+			return value, exists
+		}, nil
+	}
+
+	value := reflect.ValueOf(input)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, fmt.Errorf("input pointer cannot be nil")
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map input must have string keys, got %s", value.Type().Key())
+		}
+		return func(name string) (interface{}, bool) {
+			entry := value.MapIndex(reflect.ValueOf(name).Convert(value.Type().Key()))
+			if !entry.IsValid() {
+				return nil, false
+			}
+			return entry.Interface(), true
+		}, nil
+	case reflect.Struct:
+		return func(name string) (interface{}, bool) {
+			index, ok := structFieldIndex(value.Type(), name)
+			if !ok {
+				return nil, false
+			}
+			return value.Field(index).Interface(), true
+		}, nil
+	default:
+		return nil, fmt.Errorf("composite input must be a map or struct, got %T", input)
+	}
+}
+
+// structFieldIndex finds an exported struct field by name, json tag or
+// case-insensitive name, in that order of preference
+func structFieldIndex(structType reflect.Type, name string) (int, bool) {
+	fallback := -1
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Name == name {
+			return i, true
+		}
+		if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == name {
+			return i, true
+		}
+		if fallback < 0 && strings.EqualFold(field.Name, name) {
+			fallback = i
+		}
+	}
+	return fallback, fallback >= 0
+}
+
+// Layout returns the segment of every child in field order
+func (s *CompositeSensor) Layout() []CompositeSegment {
+	layout := make([]CompositeSegment, len(s.children))
+	for i, child := range s.children {
+		layout[i] = child.segment
+	}
+	return layout
+}
+
+// Validate checks if sensor configuration is valid
+func (s *CompositeSensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	for _, child := range s.children {
+		if err := child.sensor.Validate(); err != nil {
+			return &sensors.ValidationError{
+				Component: "composite." + child.segment.Name,
+				Reason:    err.Error(),
+			}
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *CompositeSensor) Metadata() sensors.SensorMetadata {
+	children := make(map[string]sensors.SensorMetadata, len(s.children))
+	stateful := false
+	for _, child := range s.children {
+		metadata := child.sensor.Metadata()
+		children[child.segment.Name] = metadata
+		stateful = stateful || metadata.Stateful
+	}
+
+	return s.metadata(map[string]interface{}{
+		"layout":      s.Layout(),
+		"children":    children,
+		"stateful":    stateful,
+		"input_types": []string{"map[string]interface{}", "struct"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *CompositeSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	clone.fields = append([]CompositeField(nil), s.fields...)
+	clone.children = make([]compositeChild, len(s.children))
+	for i, child := range s.children {
+		clone.children[i] = compositeChild{segment: child.segment, sensor: child.sensor.Clone()}
+	}
+	return &clone
+}
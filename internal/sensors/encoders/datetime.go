@@ -0,0 +1,483 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// dateComponent describes one calendar sub-field of the date/time encoding
+type dateComponent struct {
+	name       string                    // Parameter prefix and metadata name
+	period     float64                   // Cycle length in value units, 0 for binary components
+	resolution float64                   // Value width of a bucket for periodic components
+	buckets    int64                     // Buckets around the cycle for periodic components
+	offset     int                       // First SDR bit of the component segment
+	width      int                       // Number of SDR bits in the segment
+	activeBits int                       // Active bits contributed by the component
+	value      func(t time.Time) float64 // Extracts the component value from a timestamp
+}
+
+// DateTimeSensor encodes time.Time values as the concatenation of calendar
+// components: time of day, day of week, weekend flag, season (day of year)
+// and holiday flag. Every component owns a segment of the SDR sized by its
+// weight. Periodic components wrap around, so 23:59 and 00:01 or Sunday and
+// Monday share bits; binary components use disjoint bit sets per state.
+type DateTimeSensor struct {
+	baseSensor
+	components []dateComponent
+	holidays   map[string]struct{} // "MM-DD" annual or "YYYY-MM-DD" dates
+	location   *time.Location      // Timezone for component extraction, nil keeps the input zone
+	seed       uint64
+}
+
+// dateComponentSpec holds the defaults for a calendar component
+type dateComponentSpec struct {
+	name          string
+	enabled       bool
+	period        float64
+	defaultRadius float64
+	value         func(t time.Time) float64
+}
+
+// dateComponentSpecs lists the supported components in SDR layout order
+var dateComponentSpecs = []dateComponentSpec{
+	{name: "time_of_day", enabled: true, period: 24, defaultRadius: 4, value: func(t time.Time) float64 {
+		return float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+	}},
+	{name: "day_of_week", enabled: true, period: 7, defaultRadius: 1, value: func(t time.Time) float64 {
+		// Monday is day 0 so the weekend sits at the end of the cycle
+		return float64((int(t.Weekday())+6)%7) + float64(t.Hour())/24
+	}},
+	{name: "weekend", enabled: true, value: func(t time.Time) float64 {
+		if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+			return 1
+		}
+		return 0
+	}},
+	{name: "season", enabled: false, period: 365.25, defaultRadius: 91.5, value: func(t time.Time) float64 {
+		return float64(t.YearDay()-1) + float64(t.Hour())/24
+	}},
+	{name: "holiday", enabled: false},
+}
+
+// dateTimeParams builds the custom parameters of the date/time encoder from
+// its component specs
+func dateTimeParams() []sensors.ParamSpec {
+	var params []sensors.ParamSpec
+	for _, spec := range dateComponentSpecs {
+		enable := sensors.BoolParam(spec.name, "Encode the "+spec.name+" component")
+		if spec.name == "holiday" {
+			enable.Description += "; defaults to whether holidays are given"
+		} else {
+			enable = enable.WithDefault(spec.enabled)
+		}
+		params = append(params,
+			enable,
+			sensors.FloatParam(spec.name+"_weight", "Relative share of the SDR for "+spec.name+", positive").WithDefault(1.0))
+		if spec.period > 0 {
+			params = append(params, sensors.FloatParam(spec.name+"_radius", "Distance at which "+spec.name+" overlap reaches zero").WithDefault(spec.defaultRadius))
+		}
+	}
+
+	return append(params,
+		sensors.StringListParam("holidays", "Holiday dates in MM-DD or YYYY-MM-DD format"),
+		sensors.StringParam("timezone", "IANA time zone the components are computed in"),
+		seedParam("Seed for bucket hashing"))
+}
+
+// NewDateTimeSensor creates an unconfigured date/time encoder
+func NewDateTimeSensor() sensors.SensorInterface {
+	return &DateTimeSensor{
+		baseSensor: newBaseSensor("datetime", dateTimeParams()...),
+		seed:       defaultSeed,
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// CustomParams per component (time_of_day, day_of_week, weekend, season, holiday):
+// <component> (bool enable), <component>_weight (float64 share of the SDR, default 1),
+// <component>_radius (float64 in hours for time_of_day, days otherwise);
+// holidays ([]string of "MM-DD" or "YYYY-MM-DD", enables holiday when set),
+// timezone (IANA name), seed (int), silent_failure (bool)
+func (s *DateTimeSensor) Configure(config sensors.SensorConfig) error {
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	holidayList, err := stringListParam(cfg, "holidays")
+	if err != nil {
+		return err
+	}
+
+	holidays := make(map[string]struct{}, len(holidayList))
+	for _, day := range holidayList {
+		if _, err := time.Parse("01-02", day); err != nil {
+			if _, err := time.Parse("2006-01-02", day); err != nil {
+				return &sensors.ConfigurationError{
+					Parameter: "holidays",
+					Value:     day,
+					Reason:    "dates must use MM-DD or YYYY-MM-DD format",
+				}
+			}
+		}
+		holidays[day] = struct{}{}
+	}
+
+	var location *time.Location
+	if name := cfg.GetStringParam("timezone", ""); name != "" {
+		location, err = time.LoadLocation(name)
+		if err != nil {
+			return &sensors.ConfigurationError{
+				Parameter: "timezone",
+				Value:     name,
+				Reason:    err.Error(),
+			}
+		}
+	}
+
+	components, err := s.layoutComponents(cfg, len(holidays) > 0)
+	if err != nil {
+		return err
+	}
+
+	seed := cfg.GetIntParam("seed", defaultSeed)
+
+	s.applyConfig(cfg)
+	s.components = components
+	s.holidays = holidays
+	s.location = location
+	s.seed = uint64(seed)
+	return nil
+}
+
+// layoutComponents resolves the enabled components and splits SDR width and
+// active bits between them according to their weights
+func (s *DateTimeSensor) layoutComponents(cfg *sensors.SensorConfig, hasHolidays bool) ([]dateComponent, error) {
+	type enabledSpec struct {
+		spec   dateComponentSpec
+		weight float64
+		radius float64
+	}
+
+	enabled := make([]enabledSpec, 0, len(dateComponentSpecs))
+	totalWeight := 0.0
+	for _, spec := range dateComponentSpecs {
+		defaultEnabled := spec.enabled
+		if spec.name == "holiday" {
+			defaultEnabled = hasHolidays
+		}
+		if !cfg.GetBoolParam(spec.name, defaultEnabled) {
+			continue
+		}
+
+		if spec.name == "holiday" && !hasHolidays {
+			return nil, &sensors.ConfigurationError{
+				Parameter: "holidays",
+				Value:     nil,
+				Reason:    "holiday component requires a holidays list",
+			}
+		}
+
+		weight := cfg.GetFloatParam(spec.name+"_weight", 1.0)
+		if weight <= 0 {
+			return nil, &sensors.ConfigurationError{
+				Parameter: spec.name + "_weight",
+				Value:     weight,
+				Reason:    "must be positive",
+			}
+		}
+
+		radius := cfg.GetFloatParam(spec.name+"_radius", spec.defaultRadius)
+		if spec.period > 0 && (radius <= 0 || radius > spec.period/2) {
+			return nil, &sensors.ConfigurationError{
+				Parameter: spec.name + "_radius",
+				Value:     radius,
+				Reason:    fmt.Sprintf("must be positive and at most half the period (%g)", spec.period/2),
+			}
+		}
+
+		enabled = append(enabled, enabledSpec{spec: spec, weight: weight, radius: radius})
+		totalWeight += weight
+	}
+
+	if len(enabled) == 0 {
+		return nil, &sensors.ConfigurationError{
+			Parameter: "components",
+			Value:     nil,
+			Reason:    "at least one date/time component must be enabled",
+		}
+	}
+
+	totalActive := activeBitsFor(cfg)
+	components := make([]dateComponent, 0, len(enabled))
+	offset, assignedActive, cumulativeWeight := 0, 0, 0.0
+	for i, entry := range enabled {
+		cumulativeWeight += entry.weight
+		end := int(math.Round(float64(cfg.SDRWidth) * cumulativeWeight / totalWeight))
+		activeEnd := int(math.Round(float64(totalActive) * cumulativeWeight / totalWeight))
+		if i == len(enabled)-1 {
+			end, activeEnd = cfg.SDRWidth, totalActive
+		}
+
+		component := dateComponent{
+			name:       entry.spec.name,
+			period:     entry.spec.period,
+			offset:     offset,
+			width:      end - offset,
+			activeBits: activeEnd - assignedActive,
+			value:      entry.spec.value,
+		}
+		if component.activeBits < 1 || component.activeBits*2 > component.width {
+			return nil, &sensors.ConfigurationError{
+				Parameter: entry.spec.name + "_weight",
+				Value:     entry.weight,
+				Reason:    fmt.Sprintf("segment of %d bits cannot hold %d active bits", component.width, component.activeBits),
+			}
+		}
+
+		// Overlap falls to zero once values are a radius apart; the
+		// resolution is then evened out so whole buckets tile the period
+		// and the last bucket meets the first at the wrap point
+		if component.period > 0 {
+			resolution := entry.radius / float64(component.activeBits)
+			component.buckets = int64(math.Round(component.period / resolution))
+			component.resolution = component.period / float64(component.buckets)
+		}
+
+		components = append(components, component)
+		offset, assignedActive = end, activeEnd
+	}
+
+	return components, nil
+}
+
+// Encode converts a time.Time (or RFC 3339 string) into an SDR
+func (s *DateTimeSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	var timestamp time.Time
+	switch v := input.(type) {
+	case time.Time:
+		timestamp = v
+	case *time.Time:
+		if v == nil {
+			return s.fail(input, "time pointer cannot be nil")
+		}
+		timestamp = *v
+	case string:
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return s.fail(input, fmt.Sprintf("invalid RFC 3339 timestamp: %v", err))
+		}
+		timestamp = parsed
+	default:
+		return s.fail(input, fmt.Sprintf("unsupported date/time input type %T", input))
+	}
+
+	if s.location != nil {
+		timestamp = timestamp.In(s.location)
+	}
+
+	activeBits := make([]int, 0, s.activeBitsCount())
+	for _, component := range s.components {
+		for _, bit := range s.componentBits(component, timestamp) {
+			activeBits = append(activeBits, component.offset+bit)
+		}
+	}
+
+	return s.newSDR(activeBits)
+}
+
+// componentBits returns the segment-relative active bits of one component
+func (s *DateTimeSensor) componentBits(component dateComponent, timestamp time.Time) []int {
+	if component.period == 0 {
+		var state int
+		if component.name == "holiday" {
+			state = s.holidayState(timestamp)
+		} else {
+			state = int(component.value(timestamp))
+		}
+		return s.stateBits(component, state)
+	}
+
+	bucket := int64(math.Floor(component.value(timestamp) / component.resolution))
+	return s.bucketBits(component, bucket)
+}
+
+// stateBits returns the bits of a binary component state: each state owns
+// one half of the segment
+func (s *DateTimeSensor) stateBits(component dateComponent, state int) []int {
+	keys := make([]int64, component.activeBits)
+	for i := range keys {
+		keys[i] = int64(i)
+	}
+
+	half := component.width / 2
+	bits := hashKeys(s.componentSeed(component)+uint64(state), keys, half)
+	for i := range bits {
+		bits[i] += state * half
+	}
+	return bits
+}
+
+// bucketBits returns the bits of a periodic component bucket; consecutive
+// buckets wrap around the cycle
+func (s *DateTimeSensor) bucketBits(component dateComponent, bucket int64) []int {
+	keys := make([]int64, component.activeBits)
+	for i := range keys {
+		keys[i] = ((bucket+int64(i))%component.buckets + component.buckets) % component.buckets
+	}
+	return hashKeys(s.componentSeed(component), keys, component.width)
+}
+
+// componentSeed derives the hashing seed of a component
+func (s *DateTimeSensor) componentSeed(component dateComponent) uint64 {
+	return s.seed ^ uint64(hashString(s.seed, component.name))
+}
+
+// holidayState returns 1 when the timestamp falls on a configured holiday
+func (s *DateTimeSensor) holidayState(timestamp time.Time) int {
+	if _, annual := s.holidays[timestamp.Format("01-02")]; annual {
+		return 1
+	}
+	if _, dated := s.holidays[timestamp.Format("2006-01-02")]; dated {
+		return 1
+	}
+	return 0
+}
+
+// DateTimeComponents is a decoded date/time given as the value of every
+// enabled component: time_of_day in hours, day_of_week in days from Monday,
+// season in days from January 1st, weekend and holiday as 0 or 1
+type DateTimeComponents map[string]float64
+
+// Decode reconstructs component values from an SDR. Every component is
+// ranked on its own; candidate k combines the k-th ranked value of each
+// component (or its last one) and its confidence covers all components.
+func (s *DateTimeSensor) Decode(input sensors.SDR, maxCandidates int) ([]sensors.DecodeCandidate, error) {
+	active, err := s.decodeInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := make([][]sensors.DecodeCandidate, len(s.components))
+	count := 0
+	for i, component := range s.components {
+		ranked[i] = s.decodeComponent(component, active, maxCandidates)
+		count = max(count, len(ranked[i]))
+	}
+
+	candidates := make([]sensors.DecodeCandidate, count)
+	for k := range candidates {
+		values := make(DateTimeComponents, len(s.components))
+		found, total := 0.0, 0
+		for i, component := range s.components {
+			total += component.activeBits
+			if len(ranked[i]) == 0 {
+				continue
+			}
+			candidate := ranked[i][min(k, len(ranked[i])-1)]
+			values[component.name] = candidate.Value.(float64)
+			found += candidate.Confidence * float64(component.activeBits)
+		}
+		candidates[k] = sensors.DecodeCandidate{Value: values, Confidence: found / float64(total)}
+	}
+
+	return candidates, nil
+}
+
+// decodeComponent ranks the states or bucket centres of one component
+func (s *DateTimeSensor) decodeComponent(component dateComponent, active []bool, maxCandidates int) []sensors.DecodeCandidate {
+	if component.period == 0 {
+		scores := make([]decodeScore, 2)
+		for state := range scores {
+			scores[state] = decodeScore{
+				value:   float64(state),
+				index:   state,
+				overlap: countActive(active, component.offset, s.stateBits(component, state)),
+				total:   component.activeBits,
+			}
+		}
+		return rankCandidates(scores, maxCandidates, nil)
+	}
+
+	scores := make([]decodeScore, component.buckets)
+	for bucket := range scores {
+		scores[bucket] = decodeScore{
+			value:   math.Mod((float64(bucket)+0.5)*component.resolution, component.period),
+			index:   bucket,
+			overlap: countActive(active, component.offset, s.bucketBits(component, int64(bucket))),
+			total:   component.activeBits,
+		}
+	}
+
+	// Buckets closer than the active bit count around the cycle share keys
+	return rankCandidates(scores, maxCandidates, func(a, b decodeScore) bool {
+		distance := a.index - b.index
+		if distance < 0 {
+			distance = -distance
+		}
+		distance = min(distance, int(component.buckets)-distance)
+		return distance >= component.activeBits
+	})
+}
+
+// Validate checks if sensor configuration is valid
+func (s *DateTimeSensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if len(s.components) == 0 {
+		return &sensors.ValidationError{
+			Component: "datetime",
+			Reason:    "no date/time components enabled",
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *DateTimeSensor) Metadata() sensors.SensorMetadata {
+	layout := make([]map[string]interface{}, 0, len(s.components))
+	for _, component := range s.components {
+		layout = append(layout, map[string]interface{}{
+			"name":        component.name,
+			"offset":      component.offset,
+			"width":       component.width,
+			"active_bits": component.activeBits,
+			"periodic":    component.period > 0,
+		})
+	}
+
+	timezone := "input"
+	if s.location != nil {
+		timezone = s.location.String()
+	}
+
+	return s.metadata(map[string]interface{}{
+		"components":  layout,
+		"holidays":    len(s.holidays),
+		"timezone":    timezone,
+		"input_types": []string{"time.Time", "*time.Time", "string (RFC 3339)"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *DateTimeSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	clone.components = append([]dateComponent(nil), s.components...)
+	return &clone
+}
@@ -0,0 +1,99 @@
+package encoders
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// decodeScore is the overlap of one candidate encoding with the decoded SDR
+type decodeScore struct {
+	value   interface{} // Candidate value in input units
+	index   int         // Candidate position, used for tie breaking and suppression
+	overlap int         // Active bits of the candidate found in the SDR
+	total   int         // Active bits of the candidate encoding
+}
+
+// decodeInput checks that an SDR can be decoded by the sensor and returns
+// its active bits as a lookup table
+func (b *baseSensor) decodeInput(input sensors.SDR) ([]bool, error) {
+	if !b.configured {
+		return nil, &sensors.DecodingError{
+			SensorType: b.sensorType,
+			Reason:     "sensor must be configured before decoding",
+		}
+	}
+
+	if input == nil {
+		return nil, &sensors.DecodingError{
+			SensorType: b.sensorType,
+			Reason:     "SDR cannot be nil",
+		}
+	}
+
+	if input.Width() != b.config.SDRWidth {
+		return nil, &sensors.DecodingError{
+			SensorType: b.sensorType,
+			Reason:     fmt.Sprintf("SDR width %d does not match configured width %d", input.Width(), b.config.SDRWidth),
+		}
+	}
+
+	active := make([]bool, input.Width())
+	for _, bit := range input.ActiveBits() {
+		active[bit] = true
+	}
+	return active, nil
+}
+
+// countActive returns how many of the given bits are set in the lookup table
+func countActive(active []bool, offset int, bits []int) int {
+	count := 0
+	for _, bit := range bits {
+		if active[offset+bit] {
+			count++
+		}
+	}
+	return count
+}
+
+// rankCandidates orders scores by overlap and converts them to candidates.
+// When separated is set, a candidate conflicts with every other candidate
+// for which it returns true; conflicting lower-ranked candidates are dropped
+// so that a union of encodings decodes to its members rather than to the
+// neighbours of the strongest one. Candidates without overlap are dropped.
+func rankCandidates(scores []decodeScore, maxCandidates int, separated func(a, b decodeScore) bool) []sensors.DecodeCandidate {
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].overlap != scores[j].overlap {
+			return scores[i].overlap > scores[j].overlap
+		}
+		return scores[i].index < scores[j].index
+	})
+
+	var picked []decodeScore
+	for _, score := range scores {
+		if score.overlap == 0 || (maxCandidates > 0 && len(picked) == maxCandidates) {
+			break
+		}
+
+		conflict := false
+		for _, other := range picked {
+			if separated != nil && !separated(score, other) {
+				conflict = true
+				break
+			}
+		}
+		if !conflict {
+			picked = append(picked, score)
+		}
+	}
+
+	candidates := make([]sensors.DecodeCandidate, len(picked))
+	for i, score := range picked {
+		candidates[i] = sensors.DecodeCandidate{
+			Value:      score.value,
+			Confidence: float64(score.overlap) / float64(score.total),
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,275 @@
+package encoders
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// Change modes of the delta encoder
+const (
+	deltaModeDelta = "delta" // Encode value - previous value
+	deltaModeRate  = "rate"  // Encode (value - previous value) per second
+)
+
+// ChildSensor names a registered sensor type and its configuration
+type ChildSensor struct {
+	Type   string               // Registered sensor type
+	Config sensors.SensorConfig // Child sensor configuration
+}
+
+// DeltaInput is one observation of a stream for the delta encoder
+type DeltaInput struct {
+	Stream    string    // Stream identifier; streams keep independent history
+	Value     float64   // Observed value
+	Timestamp time.Time // Observation time, required in rate mode
+}
+
+// deltaState is the previous observation of a stream
+type deltaState struct {
+	value     float64
+	timestamp time.Time
+}
+
+// DeltaSensor encodes how a numeric stream changes rather than its level.
+// It remembers the previous observation of every stream and encodes the
+// difference (or the rate per second in "rate" mode) with a child sensor,
+// optionally followed by a child encoding of the absolute value. The first
+// observation of a stream encodes a change of zero. Child SDRs are laid out
+// like a CompositeSensor with the segments "delta" and "value".
+type DeltaSensor struct {
+	composite *CompositeSensor
+	mode      string
+
+	mutex   *sync.Mutex            // Guards the stream history
+	streams map[string]*deltaState // Previous observation per stream
+}
+
+// NewDeltaSensor creates an unconfigured delta encoder whose children are
+// created from the given registry; absolute may be nil to encode only the change
+func NewDeltaSensor(registry *sensors.Registry, delta ChildSensor, absolute *ChildSensor) *DeltaSensor {
+	fields := []CompositeField{{Name: "delta", Type: delta.Type, Config: delta.Config}}
+	if absolute != nil {
+		fields = append(fields, CompositeField{Name: "value", Type: absolute.Type, Config: absolute.Config})
+	}
+
+	composite := NewCompositeSensor(registry, fields...)
+	composite.sensorType = "delta"
+	composite.schema = append(sensors.ParamSchema{
+		sensors.StringParam("mode", "Encode the plain difference or the difference per second").
+			WithDefault(deltaModeDelta).WithEnum(deltaModeDelta, deltaModeRate),
+	}, composite.schema...)
+
+	return &DeltaSensor{
+		composite: composite,
+		mode:      deltaModeDelta,
+		mutex:     &sync.Mutex{},
+		streams:   make(map[string]*deltaState),
+	}
+}
+
+// DeltaFactory returns a factory for registering a delta encoder layout as a
+// sensor type of its own
+func DeltaFactory(registry *sensors.Registry, delta ChildSensor, absolute *ChildSensor) sensors.SensorFactory {
+	return func() sensors.SensorInterface {
+		return NewDeltaSensor(registry, delta, absolute)
+	}
+}
+
+// Configure creates and configures the child sensors and clears the stream history
+// SDRWidth and TargetSparsity are derived from the children; CustomParams:
+// mode ("delta" or "rate", default "delta"), silent_failure (bool)
+func (s *DeltaSensor) Configure(config sensors.SensorConfig) error {
+	if err := s.composite.Configure(config); err != nil {
+		return err
+	}
+
+	s.mode = s.composite.config.GetStringParam("mode", deltaModeDelta)
+	s.Reset()
+	return nil
+}
+
+// Encode records an observation and encodes its change since the previous
+// observation of the same stream. Accepts a number (default stream),
+// DeltaInput, *DeltaInput or a map with "value" and optional "stream" and
+// "timestamp" (time.Time or RFC 3339 string) keys.
+func (s *DeltaSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.composite.configured {
+		return nil, s.composite.notConfigured(input)
+	}
+
+	if err := s.composite.checkInput(input); err != nil {
+		return s.composite.fail(input, err.Error())
+	}
+
+	observation, err := toDeltaInput(input)
+	if err != nil {
+		return s.composite.fail(input, err.Error())
+	}
+
+	if !isFinite(observation.Value) {
+		return s.composite.fail(input, "value must be finite")
+	}
+
+	if s.mode == deltaModeRate && observation.Timestamp.IsZero() {
+		return s.composite.fail(input, "rate mode requires a timestamp")
+	}
+
+	// The lock spans encoding so the history only advances once the child
+	// sensors have accepted the observation; a rejected value leaves the
+	// next change unaffected
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	change, err := s.change(observation)
+	if err != nil {
+		return s.composite.fail(input, err.Error())
+	}
+
+	values := map[string]interface{}{
+		"delta": change,
+		"value": observation.Value,
+	}
+	activeBits, err := s.composite.encodeChildren(func(name string) (interface{}, bool) {
+		value, exists := values[name]
+		return value, exists
+	})
+	if err != nil {
+		return s.composite.fail(input, err.Error())
+	}
+
+	s.streams[observation.Stream] = &deltaState{value: observation.Value, timestamp: observation.Timestamp}
+	return s.composite.newSDR(activeBits)
+}
+
+// change returns the change of an observation since the previous one of its
+// stream, or zero for the first; callers hold the mutex
+func (s *DeltaSensor) change(observation DeltaInput) (float64, error) {
+	previous, seen := s.streams[observation.Stream]
+	if !seen {
+		return 0, nil
+	}
+
+	change := observation.Value - previous.value
+	if s.mode == deltaModeRate {
+		elapsed := observation.Timestamp.Sub(previous.timestamp).Seconds()
+		if elapsed <= 0 {
+			return 0, fmt.Errorf("timestamp %s is not after the previous observation %s",
+				observation.Timestamp.Format(time.RFC3339Nano), previous.timestamp.Format(time.RFC3339Nano))
+		}
+		change /= elapsed
+	}
+
+	return change, nil
+}
+
+// toDeltaInput converts the supported input forms into a DeltaInput
+func toDeltaInput(input interface{}) (DeltaInput, error) {
+	switch v := input.(type) {
+	case DeltaInput:
+		return v, nil
+	case *DeltaInput:
+		if v == nil {
+			return DeltaInput{}, fmt.Errorf("input pointer cannot be nil")
+		}
+		return *v, nil
+	case map[string]interface{}:
+		return deltaInputFromMap(v)
+	default:
+		value, err := toFloat64(input)
+		if err != nil {
+			return DeltaInput{}, err
+		}
+		return DeltaInput{Value: value}, nil
+	}
+}
+
+// deltaInputFromMap reads the "stream", "value" and "timestamp" keys of a map input
+func deltaInputFromMap(values map[string]interface{}) (DeltaInput, error) {
+	var observation DeltaInput
+
+	raw, exists := values["value"]
+	if !exists {
+		return observation, fmt.Errorf("missing field \"value\"")
+	}
+	value, err := toFloat64(raw)
+	if err != nil {
+		return observation, err
+	}
+	observation.Value = value
+
+	if stream, exists := values["stream"]; exists {
+		name, ok := stream.(string)
+		if !ok {
+			return observation, fmt.Errorf("stream must be a string, got %T", stream)
+		}
+		observation.Stream = name
+	}
+
+	switch timestamp := values["timestamp"].(type) {
+	case nil:
+	case time.Time:
+		observation.Timestamp = timestamp
+	case string:
+		parsed, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			return observation, fmt.Errorf("invalid RFC 3339 timestamp: %v", err)
+		}
+		observation.Timestamp = parsed
+	default:
+		return observation, fmt.Errorf("timestamp must be a time.Time or RFC 3339 string, got %T", timestamp)
+	}
+
+	return observation, nil
+}
+
+// Reset forgets the previous observation of every stream
+func (s *DeltaSensor) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.streams = make(map[string]*deltaState)
+}
+
+// Layout returns the segments of the delta and absolute value encodings
+func (s *DeltaSensor) Layout() []CompositeSegment {
+	return s.composite.Layout()
+}
+
+// Validate checks if sensor configuration is valid
+func (s *DeltaSensor) Validate() error {
+	return s.composite.Validate()
+}
+
+// Identity returns the sensor identity as of the last successful Configure
+func (s *DeltaSensor) Identity() sensors.SensorIdentity {
+	return s.composite.Identity()
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *DeltaSensor) Metadata() sensors.SensorMetadata {
+	s.mutex.Lock()
+	streams := len(s.streams)
+	s.mutex.Unlock()
+
+	metadata := s.composite.Metadata()
+	metadata.Capabilities["mode"] = s.mode
+	metadata.Capabilities["streams"] = streams
+	metadata.Capabilities["stateful"] = true
+	metadata.Stateful = true
+	metadata.Capabilities["input_types"] = []string{"float64", "int", "DeltaInput", "*DeltaInput", "map[string]interface{}"}
+	return metadata
+}
+
+// Clone creates a new sensor instance with same configuration and an empty
+// stream history
+func (s *DeltaSensor) Clone() sensors.SensorInterface {
+	return &DeltaSensor{
+		composite: s.composite.Clone().(*CompositeSensor),
+		mode:      s.mode,
+		mutex:     &sync.Mutex{},
+		streams:   make(map[string]*deltaState),
+	}
+}
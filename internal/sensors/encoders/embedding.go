@@ -0,0 +1,235 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// Projection methods of the embedding encoder
+const (
+	embeddingTopK    = "topk"    // Activate the bits with the largest projections
+	embeddingSimHash = "simhash" // Pick one bit per block from hyperplane signs
+)
+
+// maxEmbeddingDimensions bounds the projection matrix size
+const maxEmbeddingDimensions = 8192
+
+// EmbeddingSensor encodes dense ML embeddings into SDRs by seeded random
+// projection, so vectors with a small angle between them share many active
+// bits. With "topk" every output bit owns a random Gaussian direction and
+// the bits with the largest projections win; with "simhash" the SDR is
+// split into one block per active bit and each block selects its bit from
+// the signs of a few random hyperplanes. Both are invariant to vector
+// length and only depend on direction, i.e. on cosine similarity. SimHash
+// is the default: it projects onto a few hyperplanes per active bit rather
+// than one direction per output bit, which keeps large embeddings within
+// the 1ms encoding budget (FR-012) and the projection matrix small.
+type EmbeddingSensor struct {
+	baseSensor
+	dimensions int       // Expected input vector length
+	method     string    // Projection method
+	seed       uint64    // Seed for the projection matrix
+	planes     []float32 // Row-major projection matrix, shared read-only between clones
+	planeCount int       // Number of projection rows
+	blockSize  int       // SimHash block width
+	blockBits  int       // Hyperplanes per SimHash block
+}
+
+// embeddingParams lists the custom parameters of the embedding encoder
+var embeddingParams = []sensors.ParamSpec{
+	sensors.IntParam("dimensions", "Expected input vector length").WithRequired().WithMin(1).WithMax(maxEmbeddingDimensions),
+	sensors.StringParam("method", "Projection method").WithDefault(embeddingSimHash).WithEnum(embeddingTopK, embeddingSimHash),
+	seedParam("Seed for the projection matrix"),
+}
+
+// NewEmbeddingSensor creates an unconfigured dense embedding encoder
+func NewEmbeddingSensor() sensors.SensorInterface {
+	return &EmbeddingSensor{
+		baseSensor: newBaseSensor("embedding", embeddingParams...),
+		method:     embeddingSimHash,
+		seed:       defaultSeed,
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// CustomParams: dimensions (int, required), method ("simhash" or "topk",
+// default "simhash"), seed (int), silent_failure (bool)
+func (s *EmbeddingSensor) Configure(config sensors.SensorConfig) error {
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	dimensions := cfg.GetIntParam("dimensions", 0)
+	method := cfg.GetStringParam("method", embeddingSimHash)
+	activeBits := activeBitsFor(cfg)
+	var planeCount, blockSize, blockBits int
+	switch method {
+	case embeddingTopK:
+		planeCount = cfg.SDRWidth
+	case embeddingSimHash:
+		blockSize = cfg.SDRWidth / activeBits
+		blockBits = bits.Len(uint(blockSize)) - 1
+		if blockBits < 1 {
+			return &sensors.ConfigurationError{
+				Parameter: "method",
+				Value:     method,
+				Reason:    fmt.Sprintf("simhash needs blocks of at least 2 bits but width %d holds %d active bits", cfg.SDRWidth, activeBits),
+			}
+		}
+		planeCount = activeBits * blockBits
+	}
+
+	seed := cfg.GetIntParam("seed", defaultSeed)
+
+	s.applyConfig(cfg)
+	s.dimensions = dimensions
+	s.method = method
+	s.seed = uint64(seed)
+	s.planeCount = planeCount
+	s.blockSize = blockSize
+	s.blockBits = blockBits
+	s.planes = gaussianMatrix(s.seed, planeCount*dimensions)
+	return nil
+}
+
+// gaussianMatrix draws count standard normal values from the seeded hash
+// using the Box-Muller transform, independent of platform and Go version
+func gaussianMatrix(seed uint64, count int) []float32 {
+	values := make([]float32, count)
+	for i := 0; i < count; i += 2 {
+		u1 := 1 - unitFloat(seed, int64(i))
+		u2 := unitFloat(seed, int64(i+1))
+		radius := math.Sqrt(-2 * math.Log(u1))
+		values[i] = float32(radius * math.Cos(2*math.Pi*u2))
+		if i+1 < count {
+			values[i+1] = float32(radius * math.Sin(2*math.Pi*u2))
+		}
+	}
+	return values
+}
+
+// Encode converts a []float64 or []float32 embedding into an SDR
+func (s *EmbeddingSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	var vector []float64
+	switch v := input.(type) {
+	case []float64:
+		vector = v
+	case []float32:
+		vector = make([]float64, len(v))
+		for i, value := range v {
+			vector[i] = float64(value)
+		}
+	default:
+		return s.fail(input, fmt.Sprintf("unsupported embedding input type %T", input))
+	}
+
+	if len(vector) != s.dimensions {
+		return s.fail(input, fmt.Sprintf("embedding has %d dimensions, expected %d", len(vector), s.dimensions))
+	}
+
+	norm := 0.0
+	for _, value := range vector {
+		if !isFinite(value) {
+			return s.fail(input, "embedding values must be finite")
+		}
+		norm += value * value
+	}
+	if norm == 0 {
+		return s.fail(input, "embedding must not be the zero vector")
+	}
+
+	projections := s.project(vector)
+	if s.method == embeddingSimHash {
+		return s.newSDR(s.simHashBits(projections))
+	}
+	return s.newSDR(s.topKBits(projections))
+}
+
+// project multiplies the projection matrix with the vector
+func (s *EmbeddingSensor) project(vector []float64) []float64 {
+	projections := make([]float64, s.planeCount)
+	for row := range projections {
+		plane := s.planes[row*s.dimensions : (row+1)*s.dimensions]
+		sum := 0.0
+		for i, weight := range plane {
+			sum += float64(weight) * vector[i]
+		}
+		projections[row] = sum
+	}
+	return projections
+}
+
+// topKBits returns the bits with the largest projections, lowest index first on ties
+func (s *EmbeddingSensor) topKBits(projections []float64) []int {
+	order := make([]int, len(projections))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return projections[order[i]] > projections[order[j]]
+	})
+	return order[:s.activeBitsCount()]
+}
+
+// simHashBits selects one bit per block from the signs of its hyperplanes
+func (s *EmbeddingSensor) simHashBits(projections []float64) []int {
+	activeBits := make([]int, s.activeBitsCount())
+	for block := range activeBits {
+		cell := 0
+		for b := 0; b < s.blockBits; b++ {
+			cell <<= 1
+			if projections[block*s.blockBits+b] >= 0 {
+				cell |= 1
+			}
+		}
+		activeBits[block] = block*s.blockSize + cell
+	}
+	return activeBits
+}
+
+// Validate checks if sensor configuration is valid
+func (s *EmbeddingSensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if len(s.planes) != s.planeCount*s.dimensions {
+		return &sensors.ValidationError{
+			Component: "embedding",
+			Reason:    "projection matrix does not match the configured dimensions",
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *EmbeddingSensor) Metadata() sensors.SensorMetadata {
+	return s.metadata(map[string]interface{}{
+		"dimensions":  s.dimensions,
+		"method":      s.method,
+		"projections": s.planeCount,
+		"seed":        s.seed,
+		"input_types": []string{"[]float64", "[]float32"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *EmbeddingSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	return &clone
+}
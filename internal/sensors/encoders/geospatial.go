@@ -0,0 +1,329 @@
+package encoders
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+const (
+	// earthRadius is the WGS 84 equatorial radius used by spherical Mercator
+	earthRadius = 6378137.0
+
+	// maxMercatorLatitude is the latitude where spherical Mercator is cut off
+	maxMercatorLatitude = 85.05112878
+
+	// maxGeoRadius bounds the neighbourhood radius in cells so that an
+	// encoding, which hashes every cell of the neighbourhood, stays within 1ms
+	maxGeoRadius = 128
+)
+
+// GeoPoint is a GPS fix with speed in meters per second
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+	Speed     float64
+}
+
+// GeospatialSensor encodes GPS positions in the manner of the HTM coordinate
+// encoder. Positions are projected with spherical Mercator onto a grid of
+// scale-meter cells, the cells within a speed-dependent radius of the
+// position form its neighbourhood, and the w cells with the highest hashed
+// order are each mapped to one bit. Nearby positions share most of their
+// neighbourhood and therefore most of their bits; faster movement widens the
+// neighbourhood so positions further apart still overlap.
+type GeospatialSensor struct {
+	baseSensor
+	scale     float64 // Grid cell size in meters
+	timestep  float64 // Seconds between fixes, converts speed into distance
+	minRadius int     // Smallest neighbourhood radius in cells
+	maxRadius int     // Largest neighbourhood radius in cells
+	seed      uint64  // Seed for coordinate ordering and bit hashing
+}
+
+// geospatialParams lists the custom parameters of the geospatial encoder
+var geospatialParams = []sensors.ParamSpec{
+	sensors.FloatParam("scale", "Meters per grid cell, positive").WithDefault(30.0),
+	sensors.FloatParam("timestep", "Seconds over which speed is measured, positive").WithDefault(60.0),
+	sensors.IntParam("min_radius", "Smallest neighbourhood radius in cells; defaults to the smallest holding the active bits").WithMin(1).WithMax(maxGeoRadius),
+	sensors.IntParam("max_radius", "Largest neighbourhood radius in cells; defaults to 32 or min_radius if larger").WithMax(maxGeoRadius),
+	seedParam("Seed for cell hashing"),
+}
+
+// NewGeospatialSensor creates an unconfigured geospatial coordinate encoder
+func NewGeospatialSensor() sensors.SensorInterface {
+	return &GeospatialSensor{
+		baseSensor: newBaseSensor("geospatial", geospatialParams...),
+		scale:      30,
+		timestep:   60,
+		maxRadius:  32,
+		seed:       defaultSeed,
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// CustomParams: scale (float64 meters per cell, default 30), timestep (float64
+// seconds, default 60), min_radius (int cells, default smallest radius whose
+// neighbourhood holds the active bit count), max_radius (int cells, default 32,
+// at most 128), seed (int), silent_failure (bool)
+func (s *GeospatialSensor) Configure(config sensors.SensorConfig) error {
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	scale := cfg.GetFloatParam("scale", 30)
+	if scale <= 0 {
+		return &sensors.ConfigurationError{
+			Parameter: "scale",
+			Value:     scale,
+			Reason:    "must be a positive number of meters",
+		}
+	}
+
+	timestep := cfg.GetFloatParam("timestep", 60)
+	if timestep <= 0 {
+		return &sensors.ConfigurationError{
+			Parameter: "timestep",
+			Value:     timestep,
+			Reason:    "must be a positive number of seconds",
+		}
+	}
+
+	// The neighbourhood must hold at least one cell per active bit
+	activeBits := activeBitsFor(cfg)
+	smallestRadius := int(math.Ceil((math.Sqrt(float64(activeBits)) - 1) / 2))
+	minRadius := cfg.GetIntParam("min_radius", smallestRadius)
+	if minRadius < smallestRadius {
+		return &sensors.ConfigurationError{
+			Parameter: "min_radius",
+			Value:     minRadius,
+			Reason:    fmt.Sprintf("neighbourhood must hold %d active bits, radius must be at least %d", activeBits, smallestRadius),
+		}
+	}
+
+	maxRadius := cfg.GetIntParam("max_radius", max(32, minRadius))
+	if maxRadius < minRadius {
+		return &sensors.ConfigurationError{
+			Parameter: "max_radius",
+			Value:     maxRadius,
+			Reason:    fmt.Sprintf("must be at least min_radius (%d)", minRadius),
+		}
+	}
+
+	seed := cfg.GetIntParam("seed", defaultSeed)
+
+	s.applyConfig(cfg)
+	s.scale = scale
+	s.timestep = timestep
+	s.minRadius = minRadius
+	s.maxRadius = maxRadius
+	s.seed = uint64(seed)
+	return nil
+}
+
+// Encode converts a GPS fix into an SDR
+// Accepts GeoPoint, *GeoPoint, []float64{lat, lon[, speed]} or a map with
+// latitude/longitude/speed (or lat/lon) keys
+func (s *GeospatialSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	point, err := toGeoPoint(input)
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	if !isFinite(point.Latitude) || !isFinite(point.Longitude) || !isFinite(point.Speed) {
+		return s.fail(input, "coordinates and speed must be finite")
+	}
+	if math.Abs(point.Latitude) > maxMercatorLatitude {
+		return s.fail(input, fmt.Sprintf("latitude %g is outside the Mercator range ±%g", point.Latitude, maxMercatorLatitude))
+	}
+	if math.Abs(point.Longitude) > 180 {
+		return s.fail(input, fmt.Sprintf("longitude %g is outside [-180, 180]", point.Longitude))
+	}
+	if point.Speed < 0 {
+		return s.fail(input, "speed must not be negative")
+	}
+
+	x, y := s.coordinate(point)
+	return s.newSDR(s.coordinateBits(x, y, s.radiusForSpeed(point.Speed)))
+}
+
+// toGeoPoint converts the supported input shapes into a GeoPoint
+func toGeoPoint(input interface{}) (GeoPoint, error) {
+	switch v := input.(type) {
+	case GeoPoint:
+		return v, nil
+	case *GeoPoint:
+		if v == nil {
+			return GeoPoint{}, fmt.Errorf("geo point cannot be nil")
+		}
+		return *v, nil
+	case []float64:
+		if len(v) != 2 && len(v) != 3 {
+			return GeoPoint{}, fmt.Errorf("coordinate slice must hold latitude, longitude and optional speed, got %d values", len(v))
+		}
+		point := GeoPoint{Latitude: v[0], Longitude: v[1]}
+		if len(v) == 3 {
+			point.Speed = v[2]
+		}
+		return point, nil
+	case map[string]interface{}:
+		return geoPointFromMap(v)
+	case map[string]float64:
+		generic := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			generic[key] = value
+		}
+		return geoPointFromMap(generic)
+	default:
+		return GeoPoint{}, fmt.Errorf("unsupported geospatial input type %T", input)
+	}
+}
+
+// geoPointFromMap reads latitude, longitude and optional speed from a map
+func geoPointFromMap(values map[string]interface{}) (GeoPoint, error) {
+	field := func(required bool, keys ...string) (float64, error) {
+		for _, key := range keys {
+			if raw, ok := values[key]; ok {
+				value, err := toFloat64(raw)
+				if err != nil {
+					return 0, fmt.Errorf("field %q: %v", key, err)
+				}
+				return value, nil
+			}
+		}
+		if required {
+			return 0, fmt.Errorf("missing field %q", keys[0])
+		}
+		return 0, nil
+	}
+
+	var point GeoPoint
+	var err error
+	if point.Latitude, err = field(true, "latitude", "lat"); err != nil {
+		return GeoPoint{}, err
+	}
+	if point.Longitude, err = field(true, "longitude", "lon", "lng"); err != nil {
+		return GeoPoint{}, err
+	}
+	if point.Speed, err = field(false, "speed"); err != nil {
+		return GeoPoint{}, err
+	}
+	return point, nil
+}
+
+// coordinate projects a position onto the integer grid
+func (s *GeospatialSensor) coordinate(point GeoPoint) (int64, int64) {
+	lat := point.Latitude * math.Pi / 180
+	lon := point.Longitude * math.Pi / 180
+
+	x := earthRadius * lon
+	y := earthRadius * math.Log(math.Tan(math.Pi/4+lat/2))
+	return int64(math.Floor(x / s.scale)), int64(math.Floor(y / s.scale))
+}
+
+// radiusForSpeed returns the neighbourhood radius in cells for a speed
+func (s *GeospatialSensor) radiusForSpeed(speed float64) int {
+	// Half the distance covered in one timestep, as in the HTM coordinate encoder
+	radius := int(math.Round(speed * s.timestep / s.scale / 2))
+	return min(max(radius, s.minRadius), s.maxRadius)
+}
+
+// coordinateBits selects the top-ordered cells in the neighbourhood and hashes them into bits
+func (s *GeospatialSensor) coordinateBits(x, y int64, radius int) []int {
+	// A min-heap of the best cells so far keeps selection linear in the
+	// neighbourhood size; most cells lose against its minimum at once
+	activeBits := s.activeBitsCount()
+	top := make(geoCellHeap, 0, activeBits)
+
+	// Column hashes and the seed hash are shared by every row, leaving one
+	// mix per cell; order equals hashInt64(s.seed, key)
+	columns := make([]uint64, 2*radius+1)
+	for dx := range columns {
+		columns[dx] = mix64(uint64(x + int64(dx-radius)))
+	}
+	seedHash := mix64(s.seed)
+
+	for dy := -radius; dy <= radius; dy++ {
+		row := uint64(y + int64(dy))
+		for _, column := range columns {
+			key := int64(column ^ row)
+			candidate := geoCell{key: key, order: mix64(seedHash ^ uint64(key))}
+			if len(top) < activeBits {
+				heap.Push(&top, candidate)
+			} else if candidate.order > top[0].order {
+				top[0] = candidate
+				heap.Fix(&top, 0)
+			}
+		}
+	}
+
+	sort.Slice(top, func(i, j int) bool {
+		return top[i].order > top[j].order
+	})
+
+	keys := make([]int64, len(top))
+	for i, c := range top {
+		keys[i] = c.key
+	}
+
+	return hashKeys(s.seed^0x5bd1e995, keys, s.config.SDRWidth)
+}
+
+// geoCell is a neighbourhood cell with its hashed selection order
+type geoCell struct {
+	key   int64
+	order uint64
+}
+
+// geoCellHeap is a min-heap of cells by order for container/heap
+type geoCellHeap []geoCell
+
+func (h geoCellHeap) Len() int           { return len(h) }
+func (h geoCellHeap) Less(i, j int) bool { return h[i].order < h[j].order }
+func (h geoCellHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *geoCellHeap) Push(x interface{}) { *h = append(*h, x.(geoCell)) }
+
+func (h *geoCellHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// Validate checks if sensor configuration is valid
+func (s *GeospatialSensor) Validate() error {
+	return s.validate()
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *GeospatialSensor) Metadata() sensors.SensorMetadata {
+	return s.metadata(map[string]interface{}{
+		"scale":       s.scale,
+		"timestep":    s.timestep,
+		"min_radius":  s.minRadius,
+		"max_radius":  s.maxRadius,
+		"projection":  "spherical mercator",
+		"seed":        s.seed,
+		"input_types": []string{"GeoPoint", "*GeoPoint", "[]float64", "map[string]interface{}"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *GeospatialSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	return &clone
+}
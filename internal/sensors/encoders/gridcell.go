@@ -0,0 +1,275 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// gridModule is one grid cell module: a periodic lattice of cells with its
+// own scale and orientation
+type gridModule struct {
+	period     float64     // Spatial period of the lattice in input units
+	rotation   [][]float64 // Orientation applied to positions before the phase is taken
+	offset     int         // First SDR bit of the module segment
+	activeBits int         // Cells activated per position
+}
+
+// GridCellSensor encodes 2D or 3D positions like grid cells in the
+// entorhinal cortex. Every module tiles space with a periodic lattice of
+// side×side (×side) cells at its own scale and random orientation, and
+// activates the cells closest to the position's phase within the lattice.
+// A single module is ambiguous beyond its period, but the combination of
+// modules with incommensurate scales is unique over areas far larger than
+// any module, while nearby positions keep sharing cells in every module.
+type GridCellSensor struct {
+	baseSensor
+	dimensions int          // 2 or 3 coordinates per position
+	side       int          // Cells per lattice axis
+	modules    []gridModule // Modules in SDR layout order
+	seed       uint64       // Seed for module orientations
+}
+
+// gridCellParams lists the custom parameters of the grid cell encoder
+var gridCellParams = []sensors.ParamSpec{
+	sensors.IntParam("dimensions", "Coordinates per position").WithDefault(2).WithMin(2).WithMax(3),
+	sensors.IntParam("modules", "Grid modules, at most the active bit count; defaults to half the active bits").WithMin(1),
+	sensors.FloatParam("scale", "Period of the smallest module in input units, positive").WithDefault(1.0),
+	sensors.FloatParam("scale_ratio", "Period growth per module, greater than 1").WithDefault(1.25),
+	seedParam("Seed for module orientations"),
+}
+
+// NewGridCellSensor creates an unconfigured grid cell location encoder
+func NewGridCellSensor() sensors.SensorInterface {
+	return &GridCellSensor{
+		baseSensor: newBaseSensor("gridcell", gridCellParams...),
+		dimensions: 2,
+		seed:       defaultSeed,
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// CustomParams: dimensions (int 2 or 3, default 2), modules (int, default
+// half the active bits), scale (float64 period of the smallest module,
+// default 1), scale_ratio (float64 period growth per module, default 1.25),
+// seed (int), silent_failure (bool)
+func (s *GridCellSensor) Configure(config sensors.SensorConfig) error {
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	dimensions := cfg.GetIntParam("dimensions", 2)
+	totalActive := activeBitsFor(cfg)
+	moduleCount := cfg.GetIntParam("modules", max(1, totalActive/2))
+	if moduleCount > totalActive {
+		return &sensors.ConfigurationError{
+			Parameter: "modules",
+			Value:     moduleCount,
+			Reason:    fmt.Sprintf("must be at most the %d active bits", totalActive),
+		}
+	}
+
+	scale := cfg.GetFloatParam("scale", 1.0)
+	if scale <= 0 {
+		return &sensors.ConfigurationError{
+			Parameter: "scale",
+			Value:     scale,
+			Reason:    "must be positive",
+		}
+	}
+
+	ratio := cfg.GetFloatParam("scale_ratio", 1.25)
+	if ratio <= 1 {
+		return &sensors.ConfigurationError{
+			Parameter: "scale_ratio",
+			Value:     ratio,
+			Reason:    "must be greater than 1",
+		}
+	}
+
+	seed := cfg.GetIntParam("seed", defaultSeed)
+
+	// Every module gets an equal segment holding a side^dimensions lattice
+	segment := cfg.SDRWidth / moduleCount
+	side := int(math.Floor(math.Pow(float64(segment), 1/float64(dimensions)) + 1e-9))
+	modules := make([]gridModule, moduleCount)
+	for m := range modules {
+		active := (m+1)*totalActive/moduleCount - m*totalActive/moduleCount
+		if side < 2 || active*2 > intPow(side, dimensions) {
+			return &sensors.ConfigurationError{
+				Parameter: "modules",
+				Value:     moduleCount,
+				Reason: fmt.Sprintf("module segment of %d bits holds a lattice of side %d, too small for %d active cells",
+					segment, side, active),
+			}
+		}
+
+		modules[m] = gridModule{
+			period:     scale * math.Pow(ratio, float64(m)),
+			rotation:   randomRotation(uint64(seed)+uint64(m), dimensions),
+			offset:     m * segment,
+			activeBits: active,
+		}
+	}
+
+	s.applyConfig(cfg)
+	s.dimensions = dimensions
+	s.side = side
+	s.modules = modules
+	s.seed = uint64(seed)
+	return nil
+}
+
+// intPow returns base raised to a small non-negative exponent
+func intPow(base, exponent int) int {
+	result := 1
+	for i := 0; i < exponent; i++ {
+		result *= base
+	}
+	return result
+}
+
+// randomRotation returns a seeded rotation matrix: a random angle in 2D and
+// a random unit quaternion in 3D
+func randomRotation(seed uint64, dimensions int) [][]float64 {
+	if dimensions == 2 {
+		angle := unitFloat(seed, 0) * math.Pi / 2
+		sin, cos := math.Sincos(angle)
+		return [][]float64{{cos, -sin}, {sin, cos}}
+	}
+
+	// Uniform random unit quaternion (Shoemake)
+	u1, u2, u3 := unitFloat(seed, 0), unitFloat(seed, 1), unitFloat(seed, 2)
+	a, b := math.Sqrt(1-u1), math.Sqrt(u1)
+	w, x := a*math.Sin(2*math.Pi*u2), a*math.Cos(2*math.Pi*u2)
+	y, z := b*math.Sin(2*math.Pi*u3), b*math.Cos(2*math.Pi*u3)
+	return [][]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - z*w), 2 * (x*z + y*w)},
+		{2 * (x*y + z*w), 1 - 2*(x*x+z*z), 2 * (y*z - x*w)},
+		{2 * (x*z - y*w), 2 * (y*z + x*w), 1 - 2*(x*x+y*y)},
+	}
+}
+
+// Encode converts a []float64 (or []float32) position into an SDR
+func (s *GridCellSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	var position []float64
+	switch v := input.(type) {
+	case []float64:
+		position = v
+	case []float32:
+		position = make([]float64, len(v))
+		for i, value := range v {
+			position[i] = float64(value)
+		}
+	default:
+		return s.fail(input, fmt.Sprintf("unsupported position input type %T", input))
+	}
+
+	if len(position) != s.dimensions {
+		return s.fail(input, fmt.Sprintf("position has %d coordinates, expected %d", len(position), s.dimensions))
+	}
+
+	for _, coordinate := range position {
+		if !isFinite(coordinate) {
+			return s.fail(input, "coordinates must be finite")
+		}
+	}
+
+	activeBits := make([]int, 0, s.activeBitsCount())
+	for _, module := range s.modules {
+		for _, cell := range s.moduleCells(module, position) {
+			activeBits = append(activeBits, module.offset+cell)
+		}
+	}
+
+	return s.newSDR(activeBits)
+}
+
+// moduleCells returns the lattice cells closest to the position's phase in a module
+func (s *GridCellSensor) moduleCells(module gridModule, position []float64) []int {
+	// Phase of the rotated position within one lattice period, scaled to cell units
+	phase := make([]float64, s.dimensions)
+	for i, row := range module.rotation {
+		projected := 0.0
+		for j, weight := range row {
+			projected += weight * position[j]
+		}
+		cycles := projected / module.period
+		phase[i] = (cycles - math.Floor(cycles)) * float64(s.side)
+	}
+
+	cells := intPow(s.side, s.dimensions)
+	distances := make([]float64, cells)
+	order := make([]int, cells)
+	for cell := range distances {
+		index, distance := cell, 0.0
+		for axis := 0; axis < s.dimensions; axis++ {
+			centre := float64(index%s.side) + 0.5
+			index /= s.side
+
+			// Toroidal distance: the lattice wraps around every axis
+			delta := math.Abs(centre - phase[axis])
+			delta = math.Min(delta, float64(s.side)-delta)
+			distance += delta * delta
+		}
+		distances[cell] = distance
+		order[cell] = cell
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return distances[order[i]] < distances[order[j]]
+	})
+	return order[:module.activeBits]
+}
+
+// Validate checks if sensor configuration is valid
+func (s *GridCellSensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if len(s.modules) == 0 {
+		return &sensors.ValidationError{
+			Component: "gridcell",
+			Reason:    "no grid modules configured",
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *GridCellSensor) Metadata() sensors.SensorMetadata {
+	periods := make([]float64, len(s.modules))
+	for i, module := range s.modules {
+		periods[i] = module.period
+	}
+
+	return s.metadata(map[string]interface{}{
+		"dimensions":  s.dimensions,
+		"modules":     len(s.modules),
+		"lattice":     s.side,
+		"periods":     periods,
+		"seed":        s.seed,
+		"input_types": []string{"[]float64", "[]float32"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *GridCellSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	clone.modules = append([]gridModule(nil), s.modules...)
+	return &clone
+}
@@ -0,0 +1,72 @@
+package encoders
+
+import "hash/fnv"
+
+// defaultSeed is used by the hashing encoders when no "seed" parameter is configured
+const defaultSeed = 42
+
+// mix64 is the SplitMix64 finalizer, a fast bijective mixing function that
+// gives well distributed, platform independent hashes for integer keys
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// hashInt64 hashes an integer key under the given seed
+func hashInt64(seed uint64, key int64) uint64 {
+	return mix64(mix64(seed) ^ uint64(key))
+}
+
+// unitFloat hashes an integer key under the given seed to a float in [0, 1)
+func unitFloat(seed uint64, key int64) float64 {
+	return float64(hashInt64(seed, key)>>11) / (1 << 53)
+}
+
+// hashString derives an integer key from a string under the given seed
+func hashString(seed uint64, value string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(value))
+	return int64(mix64(seed ^ h.Sum64()))
+}
+
+// hashBits picks count distinct bit indices in [0, width) for the keys
+// key, key+1, ..., key+count-1. Each key maps to its own bit so that
+// encodings of neighbouring keys share all but the non-overlapping keys.
+func hashBits(seed uint64, key int64, count, width int) []int {
+	if count > width {
+		count = width
+	}
+
+	keys := make([]int64, count)
+	for i := range keys {
+		keys[i] = key + int64(i)
+	}
+	return hashKeys(seed, keys, width)
+}
+
+// hashKeys maps every key to a distinct bit index in [0, width); collisions
+// inside one encoding are resolved by deterministic re-probing
+func hashKeys(seed uint64, keys []int64, width int) []int {
+	if len(keys) > width {
+		keys = keys[:width]
+	}
+
+	used := make(map[int]struct{}, len(keys))
+	bits := make([]int, 0, len(keys))
+	for _, key := range keys {
+		h := hashInt64(seed, key)
+		bit := int(h % uint64(width))
+		for probe := uint64(1); ; probe++ {
+			if _, taken := used[bit]; !taken {
+				break
+			}
+			h = mix64(h + probe)
+			bit = int(h % uint64(width))
+		}
+		used[bit] = struct{}{}
+		bits = append(bits, bit)
+	}
+	return bits
+}
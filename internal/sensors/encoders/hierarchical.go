@@ -0,0 +1,204 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// HierarchicalSensor encodes taxonomy paths such as "site/building/floor/room".
+// The active bits are split across the levels of the taxonomy by weight and
+// every level hashes its bits from the path prefix up to that level, so two
+// paths share exactly the bits of their common ancestors: rooms on the same
+// floor share the site, building and floor bits, rooms in different
+// buildings only the site bits. Paths shorter than the configured depth give
+// the remaining levels to their deepest node, and segments beyond the depth
+// are folded into the last level.
+type HierarchicalSensor struct {
+	baseSensor
+	separator string // Path segment separator
+	levelBits []int  // Active bits per level, summing to the configured count
+	seed      uint64 // Seed for prefix hashing
+}
+
+// hierarchicalParams lists the custom parameters of the hierarchical encoder
+var hierarchicalParams = []sensors.ParamSpec{
+	sensors.StringParam("separator", "Path segment separator, not empty").WithDefault("/"),
+	sensors.IntParam("depth", "Taxonomy levels sharing the active bits equally").WithDefault(4).WithMin(1),
+	sensors.FloatListParam("level_weights", "Positive share of the active bits per level; sets depth when given"),
+	seedParam("Seed for prefix hashing"),
+}
+
+// NewHierarchicalSensor creates an unconfigured hierarchical categorical encoder
+func NewHierarchicalSensor() sensors.SensorInterface {
+	return &HierarchicalSensor{
+		baseSensor: newBaseSensor("hierarchical", hierarchicalParams...),
+		separator:  "/",
+		seed:       defaultSeed,
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// CustomParams: separator (string, default "/"), depth (int levels, default
+// 4), level_weights ([]float64 share of the active bits per level, default
+// equal; sets depth when given), seed (int), silent_failure (bool)
+func (s *HierarchicalSensor) Configure(config sensors.SensorConfig) error {
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	separator := cfg.GetStringParam("separator", "/")
+	if separator == "" {
+		return &sensors.ConfigurationError{
+			Parameter: "separator",
+			Value:     separator,
+			Reason:    "cannot be empty",
+		}
+	}
+
+	weights, err := floatListParam(cfg, "level_weights")
+	if err != nil {
+		return err
+	}
+
+	if weights == nil {
+		depth := cfg.GetIntParam("depth", 4)
+		weights = make([]float64, depth)
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+
+	if len(weights) == 0 {
+		return &sensors.ConfigurationError{
+			Parameter: "level_weights",
+			Value:     weights,
+			Reason:    "at least one level is required",
+		}
+	}
+
+	totalWeight := 0.0
+	for _, weight := range weights {
+		if weight <= 0 || !isFinite(weight) {
+			return &sensors.ConfigurationError{
+				Parameter: "level_weights",
+				Value:     weights,
+				Reason:    "weights must be positive",
+			}
+		}
+		totalWeight += weight
+	}
+
+	// Cumulative rounding keeps the total at the configured active bit count
+	totalActive := activeBitsFor(cfg)
+	levelBits := make([]int, len(weights))
+	assigned, cumulative := 0, 0.0
+	for i, weight := range weights {
+		cumulative += weight
+		end := int(math.Round(float64(totalActive) * cumulative / totalWeight))
+		levelBits[i] = end - assigned
+		assigned = end
+		if levelBits[i] < 1 {
+			return &sensors.ConfigurationError{
+				Parameter: "level_weights",
+				Value:     weights,
+				Reason:    fmt.Sprintf("level %d receives no active bits out of %d", i+1, totalActive),
+			}
+		}
+	}
+
+	seed := cfg.GetIntParam("seed", defaultSeed)
+
+	s.applyConfig(cfg)
+	s.separator = separator
+	s.levelBits = levelBits
+	s.seed = uint64(seed)
+	return nil
+}
+
+// Encode converts a path string or a []string of path segments into an SDR
+func (s *HierarchicalSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	var raw []string
+	switch v := input.(type) {
+	case string:
+		raw = strings.Split(v, s.separator)
+	case []string:
+		raw = v
+	default:
+		return s.fail(input, fmt.Sprintf("unsupported hierarchical input type %T", input))
+	}
+
+	segments := make([]string, 0, len(raw))
+	for _, segment := range raw {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) == 0 {
+		return s.fail(input, "path must contain at least one segment")
+	}
+
+	// Ancestor keys come first so that hashKeys resolves their collisions
+	// identically for every path below them
+	keys := make([]int64, 0, s.activeBitsCount())
+	for level, count := range s.levelBits {
+		prefix := segments[:min(level+1, len(segments))]
+		if level == len(s.levelBits)-1 {
+			prefix = segments
+		}
+
+		base := hashString(s.seed, strconv.Itoa(level)+"\x00"+strings.Join(prefix, "\x00"))
+		for i := 0; i < count; i++ {
+			keys = append(keys, base+int64(i))
+		}
+	}
+
+	return s.newSDR(hashKeys(s.seed, keys, s.config.SDRWidth))
+}
+
+// Validate checks if sensor configuration is valid
+func (s *HierarchicalSensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if len(s.levelBits) == 0 {
+		return &sensors.ValidationError{
+			Component: "hierarchical",
+			Reason:    "no taxonomy levels configured",
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *HierarchicalSensor) Metadata() sensors.SensorMetadata {
+	return s.metadata(map[string]interface{}{
+		"separator":   s.separator,
+		"depth":       len(s.levelBits),
+		"level_bits":  append([]int(nil), s.levelBits...),
+		"seed":        s.seed,
+		"input_types": []string{"string", "[]string"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *HierarchicalSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	clone.levelBits = append([]int(nil), s.levelBits...)
+	return &clone
+}
@@ -0,0 +1,286 @@
+package encoders
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// Missing-field policies of the JSON encoder
+const (
+	jsonMissingFail    = "fail"    // Fail the whole document (silent failure or error)
+	jsonMissingEmpty   = "empty"   // Leave the field's segment without active bits
+	jsonMissingDefault = "default" // Encode the field's default value instead
+)
+
+// JSONField maps a path in a JSON document to a child sensor
+type JSONField struct {
+	Path    string               // Dot-separated path; numeric segments index arrays, e.g. "readings.0.value"
+	Type    string               // Registered sensor type of the child
+	Config  sensors.SensorConfig // Child sensor configuration
+	Missing string               // Missing-field policy; empty uses the sensor's "missing" parameter
+	Default interface{}          // Value encoded when the field is missing under the "default" policy
+}
+
+// JSONSensor encodes raw JSON documents against a field schema. Every
+// schema path is looked up in the decoded document and encoded by its own
+// child sensor, and the child SDRs are concatenated in schema order like a
+// CompositeSensor whose field names are the paths. A path that is absent
+// or null is handled by its missing-field policy. JSON numbers reach the
+// children as float64, arrays of numbers as []float64, arrays of strings
+// as []string and objects as map[string]interface{}.
+type JSONSensor struct {
+	composite *CompositeSensor
+	fields    []JSONField
+	policies  map[string]string      // Resolved missing-field policy per path
+	defaults  map[string]interface{} // Default value per path
+}
+
+// NewJSONSensor creates an unconfigured JSON document encoder whose children
+// are created from the given registry
+func NewJSONSensor(registry *sensors.Registry, fields ...JSONField) *JSONSensor {
+	children := make([]CompositeField, len(fields))
+	defaults := make(map[string]interface{}, len(fields))
+	for i, field := range fields {
+		children[i] = CompositeField{Name: field.Path, Type: field.Type, Config: field.Config}
+		defaults[field.Path] = field.Default
+	}
+
+	composite := NewCompositeSensor(registry, children...)
+	composite.sensorType = "json"
+	composite.schema = append(sensors.ParamSchema{
+		sensors.StringParam("missing", "Default policy for fields absent from the document").
+			WithDefault(jsonMissingFail).WithEnum(jsonMissingFail, jsonMissingEmpty, jsonMissingDefault),
+	}, composite.schema...)
+
+	return &JSONSensor{
+		composite: composite,
+		fields:    append([]JSONField(nil), fields...),
+		defaults:  defaults,
+	}
+}
+
+// JSONFactory returns a factory for registering a JSON schema as a sensor
+// type of its own
+func JSONFactory(registry *sensors.Registry, fields ...JSONField) sensors.SensorFactory {
+	return func() sensors.SensorInterface {
+		return NewJSONSensor(registry, fields...)
+	}
+}
+
+// Configure resolves the missing-field policies and configures the child sensors
+// SDRWidth and TargetSparsity are derived from the children; CustomParams:
+// missing ("fail", "empty" or "default", default "fail"), silent_failure (bool)
+func (s *JSONSensor) Configure(config sensors.SensorConfig) error {
+	// The schema checks the policy before any child is configured
+	params := config.Clone()
+	if err := s.composite.schema.Apply(params); err != nil {
+		return err
+	}
+
+	fallback := params.GetStringParam("missing", jsonMissingFail)
+
+	policies := make(map[string]string, len(s.fields))
+	for _, field := range s.fields {
+		for _, segment := range strings.Split(field.Path, ".") {
+			if segment == "" {
+				return &sensors.ConfigurationError{
+					Parameter: "fields",
+					Value:     field.Path,
+					Reason:    "path cannot contain empty segments",
+				}
+			}
+		}
+
+		policy := field.Missing
+		if policy == "" {
+			policy = fallback
+		}
+		if !isJSONMissingPolicy(policy) {
+			return &sensors.ConfigurationError{
+				Parameter: "fields." + field.Path,
+				Value:     policy,
+				Reason:    fmt.Sprintf("missing policy must be '%s', '%s' or '%s'", jsonMissingFail, jsonMissingEmpty, jsonMissingDefault),
+			}
+		}
+		if policy == jsonMissingDefault && field.Default == nil {
+			return &sensors.ConfigurationError{
+				Parameter: "fields." + field.Path,
+				Value:     policy,
+				Reason:    "default policy requires a default value",
+			}
+		}
+		if _, err := json.Marshal(field.Default); err != nil {
+			return &sensors.ConfigurationError{
+				Parameter: "fields." + field.Path,
+				Value:     field.Default,
+				Reason:    "default value cannot be serialized: " + err.Error(),
+			}
+		}
+		policies[field.Path] = policy
+	}
+
+	if err := s.composite.Configure(config); err != nil {
+		return err
+	}
+
+	s.policies = policies
+	s.composite.identifyChildren(map[string]interface{}{"missing": policies, "defaults": s.defaults})
+	return nil
+}
+
+// isJSONMissingPolicy reports whether policy names a missing-field policy
+func isJSONMissingPolicy(policy string) bool {
+	return policy == jsonMissingFail || policy == jsonMissingEmpty || policy == jsonMissingDefault
+}
+
+// Encode decodes a JSON document given as []byte, json.RawMessage or string
+// and encodes the schema fields
+func (s *JSONSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.composite.configured {
+		return nil, s.composite.notConfigured(input)
+	}
+
+	var data []byte
+	switch v := input.(type) {
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		return s.composite.fail(input, "input cannot be nil")
+	default:
+		return s.composite.fail(input, fmt.Sprintf("unsupported JSON input type %T", input))
+	}
+
+	// Size is checked on the raw bytes before anything is decoded
+	if err := s.composite.checkInput(data); err != nil {
+		return s.composite.fail(input, err.Error())
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return s.composite.fail(input, fmt.Sprintf("invalid JSON: %v", err))
+	}
+
+	return s.composite.encodeFields(input, func(path string) (interface{}, bool) {
+		if value, found := jsonPath(document, path); found {
+			return normalizeJSONValue(value), true
+		}
+
+		switch s.policies[path] {
+		case jsonMissingEmpty:
+			return emptySegment{}, true
+		case jsonMissingDefault:
+			return s.defaults[path], true
+		default:
+			return nil, false
+		}
+	})
+}
+
+// jsonPath resolves a dot-separated path in a decoded document; null
+// values count as missing
+func jsonPath(document interface{}, path string) (interface{}, bool) {
+	current := document
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, exists := node[segment]
+			if !exists {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, current != nil
+}
+
+// normalizeJSONValue converts homogeneous JSON arrays into the []float64 or
+// []string inputs accepted by the built-in encoders
+func normalizeJSONValue(value interface{}) interface{} {
+	array, ok := value.([]interface{})
+	if !ok || len(array) == 0 {
+		return value
+	}
+
+	switch array[0].(type) {
+	case float64:
+		numbers := make([]float64, len(array))
+		for i, element := range array {
+			number, ok := element.(float64)
+			if !ok {
+				return value
+			}
+			numbers[i] = number
+		}
+		return numbers
+	case string:
+		strs := make([]string, len(array))
+		for i, element := range array {
+			str, ok := element.(string)
+			if !ok {
+				return value
+			}
+			strs[i] = str
+		}
+		return strs
+	default:
+		return value
+	}
+}
+
+// Layout returns the segment of every schema path in schema order
+func (s *JSONSensor) Layout() []CompositeSegment {
+	return s.composite.Layout()
+}
+
+// Validate checks if sensor configuration is valid
+func (s *JSONSensor) Validate() error {
+	return s.composite.Validate()
+}
+
+// Identity returns the sensor identity as of the last successful Configure
+func (s *JSONSensor) Identity() sensors.SensorIdentity {
+	return s.composite.Identity()
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *JSONSensor) Metadata() sensors.SensorMetadata {
+	policies := make(map[string]string, len(s.policies))
+	for path, policy := range s.policies {
+		policies[path] = policy
+	}
+
+	metadata := s.composite.Metadata()
+	metadata.Capabilities["missing"] = policies
+	metadata.Capabilities["input_types"] = []string{"[]byte", "json.RawMessage", "string"}
+	return metadata
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *JSONSensor) Clone() sensors.SensorInterface {
+	clone := &JSONSensor{
+		composite: s.composite.Clone().(*CompositeSensor),
+		fields:    append([]JSONField(nil), s.fields...),
+		policies:  make(map[string]string, len(s.policies)),
+		defaults:  s.defaults,
+	}
+	for path, policy := range s.policies {
+		clone.policies[path] = policy
+	}
+	return clone
+}
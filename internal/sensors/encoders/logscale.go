@@ -0,0 +1,155 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// LogNumericSensor encodes positive values spanning several orders of
+// magnitude, such as latencies or byte counts. Values are encoded by a
+// bounded numeric layout over log10 of the input, so equal ratios map to
+// equal bit distances: 1ms and 2ms are as similar as 100ms and 200ms.
+type LogNumericSensor struct {
+	baseSensor
+	linear    NumericSensor // Numeric layout over the log10 range
+	minValue  float64       // Lower bound of the encoded range, in input units
+	maxValue  float64       // Upper bound of the encoded range, in input units
+	clipInput bool          // Clip out-of-range values instead of failing
+}
+
+// logParams lists the custom parameters of the logarithmic encoder
+var logParams = []sensors.ParamSpec{
+	sensors.BoolParam("clip_input", "Clamp values outside Range to its bounds instead of failing").WithDefault(false),
+}
+
+// NewLogNumericSensor creates an unconfigured logarithmic numeric encoder
+func NewLogNumericSensor() sensors.SensorInterface {
+	return &LogNumericSensor{
+		baseSensor: newBaseSensor("log", logParams...),
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// Uses Range (Min must be positive) and Resolution in decades, e.g. 0.01 for
+// about 2.3% relative precision; CustomParams: clip_input (bool),
+// silent_failure (bool)
+func (s *LogNumericSensor) Configure(config sensors.SensorConfig) error {
+	if config.Range == nil {
+		return &sensors.ConfigurationError{
+			Parameter: "range",
+			Value:     nil,
+			Reason:    "log encoder requires a bounded range",
+		}
+	}
+
+	if err := config.ValidateRange(); err != nil {
+		return err
+	}
+
+	if config.Range.Min <= 0 {
+		return &sensors.ConfigurationError{
+			Parameter: "range",
+			Value:     config.Range.Min,
+			Reason:    "log encoder range minimum must be positive",
+		}
+	}
+
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	linearConfig := cfg.Clone()
+	linearConfig.Range = &sensors.Range{Min: math.Log10(cfg.Range.Min), Max: math.Log10(cfg.Range.Max)}
+	linear := NumericSensor{baseSensor: newBaseSensor(s.sensorType, numericParams...)}
+	if err := linear.Configure(*linearConfig); err != nil {
+		return err
+	}
+
+	s.applyConfig(cfg)
+	s.linear = linear
+	s.minValue = cfg.Range.Min
+	s.maxValue = cfg.Range.Max
+	s.clipInput = cfg.GetBoolParam("clip_input", false)
+	return nil
+}
+
+// Encode converts a positive numeric value into an SDR
+func (s *LogNumericSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	value, err := toFloat64(input)
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	if !isFinite(value) {
+		return s.fail(input, "value must be finite")
+	}
+
+	if value < s.minValue || value > s.maxValue {
+		if !s.clipInput {
+			return s.fail(input, fmt.Sprintf("value %g outside range [%g, %g]", value, s.minValue, s.maxValue))
+		}
+		value = math.Max(s.minValue, math.Min(s.maxValue, value))
+	}
+
+	bucket := s.linear.bucketIndex(math.Log10(value))
+	return s.newSDR(s.linear.fillBucket(make([]int, s.activeBitsCount()), bucket))
+}
+
+// Decode reconstructs values from an SDR in input units
+func (s *LogNumericSensor) Decode(input sensors.SDR, maxCandidates int) ([]sensors.DecodeCandidate, error) {
+	if _, err := s.decodeInput(input); err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.linear.Decode(input, maxCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range candidates {
+		candidates[i].Value = math.Pow(10, candidates[i].Value.(float64))
+	}
+	return candidates, nil
+}
+
+// Validate checks if sensor configuration is valid
+func (s *LogNumericSensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	return s.linear.Validate()
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *LogNumericSensor) Metadata() sensors.SensorMetadata {
+	return s.metadata(map[string]interface{}{
+		"min_value":   s.minValue,
+		"max_value":   s.maxValue,
+		"resolution":  s.linear.resolution,
+		"buckets":     s.linear.buckets,
+		"clip_input":  s.clipInput,
+		"radius":      s.linear.radius(),
+		"scale":       "log10",
+		"input_types": []string{"float64", "float32", "int", "int64", "int32", "uint"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *LogNumericSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	clone.linear.baseSensor = s.linear.clone()
+	return &clone
+}
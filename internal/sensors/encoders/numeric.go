@@ -0,0 +1,246 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// NumericSensor encodes bounded scalar values as a single contiguous block of
+// active bits whose position tracks the value within the configured range.
+// Values closer than the encoder radius share active bits in proportion to
+// their distance, values further apart share none.
+type NumericSensor struct {
+	baseSensor
+	minValue   float64 // Lower bound of the encoded range
+	maxValue   float64 // Upper bound of the encoded range
+	resolution float64 // Value distance between adjacent buckets
+	buckets    int     // Number of distinguishable value buckets
+	positions  int     // Number of possible start positions for the active block
+	clipInput  bool    // Clip out-of-range values instead of failing
+}
+
+// numericParams lists the custom parameters of the numeric encoder
+var numericParams = []sensors.ParamSpec{
+	sensors.BoolParam("clip_input", "Clamp values outside Range to its bounds instead of failing").WithDefault(false),
+}
+
+// NewNumericSensor creates an unconfigured bounded scalar encoder
+func NewNumericSensor() sensors.SensorInterface {
+	return &NumericSensor{
+		baseSensor: newBaseSensor("numeric", numericParams...),
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// Uses Range and Resolution from the config; CustomParams: clip_input (bool),
+// silent_failure (bool)
+func (s *NumericSensor) Configure(config sensors.SensorConfig) error {
+	if config.Range == nil {
+		return &sensors.ConfigurationError{
+			Parameter: "range",
+			Value:     nil,
+			Reason:    "numeric encoder requires a bounded range",
+		}
+	}
+
+	if err := config.ValidateResolution(); err != nil {
+		return err
+	}
+
+	if err := config.ValidateRange(); err != nil {
+		return err
+	}
+
+	span := config.Range.Max - config.Range.Min
+	if config.Resolution >= span {
+		return &sensors.ConfigurationError{
+			Parameter: "resolution",
+			Value:     config.Resolution,
+			Reason:    fmt.Sprintf("must be smaller than the range span %.3f", span),
+		}
+	}
+
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	positions := cfg.SDRWidth - activeBitsFor(cfg) + 1
+	buckets := int(math.Round(span/config.Resolution)) + 1
+	if buckets > positions {
+		return &sensors.ConfigurationError{
+			Parameter: "resolution",
+			Value:     config.Resolution,
+			Reason: fmt.Sprintf("range needs %d buckets but SDR width %d only has %d positions; increase width or resolution",
+				buckets, cfg.SDRWidth, positions),
+		}
+	}
+
+	s.applyConfig(cfg)
+	s.minValue = config.Range.Min
+	s.maxValue = config.Range.Max
+	s.resolution = config.Resolution
+	s.buckets = buckets
+	s.positions = positions
+	s.clipInput = s.config.GetBoolParam("clip_input", false)
+	return nil
+}
+
+// Encode converts a numeric value into an SDR
+func (s *NumericSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	return s.encodeInto(input, make([]int, s.activeBitsCount()))
+}
+
+// EncodeBatch encodes inputs in order, sharing one scratch buffer for the
+// active bits of all items
+func (s *NumericSensor) EncodeBatch(inputs []interface{}, results []sensors.BatchResult) {
+	if !s.configured {
+		for i, input := range inputs {
+			results[i] = sensors.BatchResult{Err: s.notConfigured(input)}
+		}
+		return
+	}
+
+	buffer := make([]int, s.activeBitsCount())
+	for i, input := range inputs {
+		sdr, err := s.encodeInto(input, buffer)
+		results[i] = sensors.BatchResult{SDR: sdr, Err: err}
+	}
+}
+
+// encodeInto encodes a value using buffer for the active bits
+func (s *NumericSensor) encodeInto(input interface{}, buffer []int) (sensors.SDR, error) {
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	value, err := toFloat64(input)
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	if !isFinite(value) {
+		return s.fail(input, "value must be finite")
+	}
+
+	if value < s.minValue || value > s.maxValue {
+		if !s.clipInput {
+			return s.fail(input, fmt.Sprintf("value %g outside range [%g, %g]", value, s.minValue, s.maxValue))
+		}
+		value = math.Max(s.minValue, math.Min(s.maxValue, value))
+	}
+
+	return s.newSDR(s.fillBucket(buffer, s.bucketIndex(value)))
+}
+
+// bucketIndex maps an in-range value to its bucket
+func (s *NumericSensor) bucketIndex(value float64) int {
+	bucket := int(math.Round((value - s.minValue) / s.resolution))
+	if bucket < 0 {
+		return 0
+	}
+	if bucket >= s.buckets {
+		return s.buckets - 1
+	}
+	return bucket
+}
+
+// fillBucket writes the contiguous active bits for a bucket into activeBits,
+// spreading the buckets evenly over all available start positions
+func (s *NumericSensor) fillBucket(activeBits []int, bucket int) []int {
+	start := s.bucketStart(bucket)
+	for i := range activeBits {
+		activeBits[i] = start + i
+	}
+	return activeBits
+}
+
+// bucketStart returns the first active bit of a bucket
+func (s *NumericSensor) bucketStart(bucket int) int {
+	return int(math.Round(float64(bucket) * float64(s.positions-1) / float64(s.buckets-1)))
+}
+
+// radius returns the value distance at which two encodings stop overlapping
+func (s *NumericSensor) radius() float64 {
+	if s.buckets < 2 {
+		return 0
+	}
+	bitsPerBucket := float64(s.positions-1) / float64(s.buckets-1)
+	return float64(s.activeBitsCount()) / bitsPerBucket * s.resolution
+}
+
+// Decode reconstructs bucket values from an SDR; candidates are at least
+// one encoder radius apart so a union of encodings yields its members
+func (s *NumericSensor) Decode(input sensors.SDR, maxCandidates int) ([]sensors.DecodeCandidate, error) {
+	active, err := s.decodeInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefix sums give the overlap of every contiguous block in O(1)
+	prefix := make([]int, len(active)+1)
+	for i, set := range active {
+		prefix[i+1] = prefix[i]
+		if set {
+			prefix[i+1]++
+		}
+	}
+
+	activeBits := s.activeBitsCount()
+	scores := make([]decodeScore, s.buckets)
+	for bucket := range scores {
+		start := s.bucketStart(bucket)
+		scores[bucket] = decodeScore{
+			value:   math.Min(s.maxValue, s.minValue+float64(bucket)*s.resolution),
+			index:   start,
+			overlap: prefix[start+activeBits] - prefix[start],
+			total:   activeBits,
+		}
+	}
+
+	return rankCandidates(scores, maxCandidates, func(a, b decodeScore) bool {
+		return a.index-b.index >= activeBits || b.index-a.index >= activeBits
+	}), nil
+}
+
+// Validate checks if sensor configuration is valid
+func (s *NumericSensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if s.buckets < 2 || s.buckets > s.positions {
+		return &sensors.ValidationError{
+			Component: "numeric",
+			Reason:    fmt.Sprintf("invalid bucket layout: %d buckets over %d positions", s.buckets, s.positions),
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *NumericSensor) Metadata() sensors.SensorMetadata {
+	return s.metadata(map[string]interface{}{
+		"min_value":   s.minValue,
+		"max_value":   s.maxValue,
+		"resolution":  s.resolution,
+		"buckets":     s.buckets,
+		"clip_input":  s.clipInput,
+		"radius":      s.radius(),
+		"input_types": []string{"float64", "float32", "int", "int64", "int32", "uint"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *NumericSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	return &clone
+}
@@ -0,0 +1,254 @@
+package encoders
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/sdr"
+)
+
+// PassthroughSensor accepts SDRs that were encoded upstream, so they can be
+// composed with the built-in encoders. Input is either a list of active bit
+// indices ([]int, []int32, []int64, []uint32) or a packed bitset ([]uint64
+// words or []byte, least significant bit first) of the configured width.
+// Duplicate indices are merged and the result must pass SDRValidator.
+//
+// With "subsample" an input with more active bits than the target keeps the
+// bits with the smallest seeded hash, and with "pad" an input with fewer
+// active bits gains hashed partner bits of its own active bits. Both choices
+// depend only on the bits involved, so similar inputs stay similar.
+type PassthroughSensor struct {
+	baseSensor
+	validator *sensors.SDRValidator
+	subsample bool   // Reduce inputs above the target to the target count
+	pad       bool   // Extend inputs below the target to the target count
+	seed      uint64 // Seed for subsampling and padding
+}
+
+// passthroughParams lists the custom parameters of the pass-through sensor
+var passthroughParams = []sensors.ParamSpec{
+	sensors.BoolParam("subsample", "Reduce inputs above the target sparsity to the target count").WithDefault(false),
+	sensors.BoolParam("pad", "Extend inputs below the target sparsity to the target count").WithDefault(false),
+	seedParam("Seed for subsampling and padding"),
+}
+
+// NewPassthroughSensor creates an unconfigured pre-encoded SDR sensor
+func NewPassthroughSensor() sensors.SensorInterface {
+	return &PassthroughSensor{
+		baseSensor: newBaseSensor("passthrough", passthroughParams...),
+		seed:       defaultSeed,
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// CustomParams: subsample (bool, default false), pad (bool, default false),
+// seed (int), silent_failure (bool)
+func (s *PassthroughSensor) Configure(config sensors.SensorConfig) error {
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	validator, err := sensors.NewSDRValidator(cfg.TargetSparsity, cfg.GetBoolParam("silent_failure", true))
+	if err != nil {
+		return &sensors.ConfigurationError{
+			Parameter: "TargetSparsity",
+			Value:     cfg.TargetSparsity,
+			Reason:    err.Error(),
+		}
+	}
+
+	seed := cfg.GetIntParam("seed", defaultSeed)
+
+	s.applyConfig(cfg)
+	s.validator = validator
+	s.subsample = cfg.GetBoolParam("subsample", false)
+	s.pad = cfg.GetBoolParam("pad", false)
+	s.seed = uint64(seed)
+	return nil
+}
+
+// Encode validates a pre-encoded index list or packed bitset and returns it as an SDR
+func (s *PassthroughSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	activeBits, err := s.activeBitsOf(input)
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	internal, err := sdr.NewSDR(s.config.SDRWidth, activeBits)
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	target := s.activeBitsCount()
+	switch count := len(internal.ActiveBits()); {
+	case count > target && s.subsample:
+		internal, err = sdr.NewSDR(s.config.SDRWidth, s.subsampleBits(internal.ActiveBits(), target))
+	case count < target && count > 0 && s.pad:
+		internal, err = sdr.NewSDR(s.config.SDRWidth, s.padBits(internal.ActiveBits(), target))
+	}
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	if err := s.validator.ValidateSDR(internal); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	return sensors.NewFingerprintedSDR(internal, s.fingerprint), nil
+}
+
+// activeBitsOf converts an index list or packed bitset into active bit indices
+func (s *PassthroughSensor) activeBitsOf(input interface{}) ([]int, error) {
+	switch v := input.(type) {
+	case []int:
+		return v, nil
+	case []int32:
+		activeBits := make([]int, len(v))
+		for i, index := range v {
+			activeBits[i] = int(index)
+		}
+		return activeBits, nil
+	case []int64:
+		activeBits := make([]int, len(v))
+		for i, index := range v {
+			activeBits[i] = int(index)
+		}
+		return activeBits, nil
+	case []uint32:
+		activeBits := make([]int, len(v))
+		for i, index := range v {
+			activeBits[i] = int(index)
+		}
+		return activeBits, nil
+	case []uint64:
+		words := (s.config.SDRWidth + 63) / 64
+		if len(v) != words {
+			return nil, fmt.Errorf("bitset has %d words, expected %d for width %d", len(v), words, s.config.SDRWidth)
+		}
+		var activeBits []int
+		for i, word := range v {
+			for word != 0 {
+				activeBits = append(activeBits, i*64+bits.TrailingZeros64(word))
+				word &= word - 1
+			}
+		}
+		return activeBits, nil
+	case []byte:
+		size := (s.config.SDRWidth + 7) / 8
+		if len(v) != size {
+			return nil, fmt.Errorf("bitset has %d bytes, expected %d for width %d", len(v), size, s.config.SDRWidth)
+		}
+		var activeBits []int
+		for i, b := range v {
+			for b != 0 {
+				activeBits = append(activeBits, i*8+bits.TrailingZeros8(b))
+				b &= b - 1
+			}
+		}
+		return activeBits, nil
+	default:
+		return nil, fmt.Errorf("unsupported pre-encoded SDR input type %T", input)
+	}
+}
+
+// subsampleBits keeps the target count of active bits with the smallest seeded hash
+func (s *PassthroughSensor) subsampleBits(activeBits []int, target int) []int {
+	kept := append([]int(nil), activeBits...)
+	sort.Slice(kept, func(i, j int) bool {
+		return hashInt64(s.seed, int64(kept[i])) < hashInt64(s.seed, int64(kept[j]))
+	})
+	return kept[:target]
+}
+
+// padBits adds hashed partner bits of the active bits until the target count
+// is reached, preferring the partners with the smallest hash
+func (s *PassthroughSensor) padBits(activeBits []int, target int) []int {
+	width := s.config.SDRWidth
+	active := make(map[int]struct{}, target)
+	for _, bit := range activeBits {
+		active[bit] = struct{}{}
+	}
+
+	type partner struct {
+		bit  int
+		hash uint64
+	}
+
+	need := target - len(activeBits)
+	for rounds := need/len(activeBits) + 1; ; rounds *= 2 {
+		var partners []partner
+		for _, bit := range activeBits {
+			for r := 0; r < rounds; r++ {
+				h := hashInt64(s.seed, int64(bit)*int64(width)+int64(r))
+				partners = append(partners, partner{bit: int(h % uint64(width)), hash: h})
+			}
+		}
+		sort.Slice(partners, func(i, j int) bool {
+			return partners[i].hash < partners[j].hash
+		})
+
+		padded := append([]int(nil), activeBits...)
+		added := make(map[int]struct{}, need)
+		for _, p := range partners {
+			if len(padded) == target {
+				break
+			}
+			if _, taken := active[p.bit]; taken {
+				continue
+			}
+			if _, taken := added[p.bit]; taken {
+				continue
+			}
+			added[p.bit] = struct{}{}
+			padded = append(padded, p.bit)
+		}
+
+		if len(padded) == target || rounds >= width {
+			return padded
+		}
+	}
+}
+
+// Validate checks if sensor configuration is valid
+func (s *PassthroughSensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if s.validator == nil {
+		return &sensors.ValidationError{
+			Component: "passthrough",
+			Reason:    "SDR validator is not initialized",
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *PassthroughSensor) Metadata() sensors.SensorMetadata {
+	return s.metadata(map[string]interface{}{
+		"subsample":   s.subsample,
+		"pad":         s.pad,
+		"seed":        s.seed,
+		"input_types": []string{"[]int", "[]int32", "[]int64", "[]uint32", "[]uint64", "[]byte"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *PassthroughSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	return &clone
+}
@@ -0,0 +1,157 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// PeriodicSensor encodes cyclic values such as angles, headings or machine
+// phases. The configured Range is one period: values wrap around it, so the
+// two ends of the range are neighbours and values outside it are folded back
+// in (370° encodes like 10°). Each value activates the hashed bits of its
+// bucket and the following buckets around the cycle, so values closer than
+// the radius share bits in proportion to their distance.
+type PeriodicSensor struct {
+	baseSensor
+	minValue   float64 // Start of the period
+	period     float64 // Length of the period in value units
+	resolution float64 // Value width of a bucket
+	buckets    int64   // Buckets around the cycle
+	seed       uint64  // Seed for the bucket hash
+}
+
+// periodicParams lists the custom parameters of the periodic encoder
+var periodicParams = []sensors.ParamSpec{
+	sensors.FloatParam("radius", "Value distance at which overlap reaches zero; overrides Resolution"),
+	seedParam("Seed for bucket hashing"),
+}
+
+// NewPeriodicSensor creates an unconfigured periodic encoder
+func NewPeriodicSensor() sensors.SensorInterface {
+	return &PeriodicSensor{
+		baseSensor: newBaseSensor("periodic", periodicParams...),
+		seed:       defaultSeed,
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// Uses Range as the period and Resolution as the bucket width; CustomParams:
+// radius (float64 value distance at which overlap reaches zero, overrides
+// Resolution), seed (int), silent_failure (bool)
+func (s *PeriodicSensor) Configure(config sensors.SensorConfig) error {
+	if config.Range == nil {
+		return &sensors.ConfigurationError{
+			Parameter: "range",
+			Value:     nil,
+			Reason:    "periodic encoder requires a range defining the period",
+		}
+	}
+
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	// Validate after the schema has coerced radius to float64
+	if err := cfg.IsValid(); err != nil {
+		return err
+	}
+
+	activeBits := activeBitsFor(cfg)
+	period := cfg.Range.Max - cfg.Range.Min
+	resolution := cfg.Resolution
+	if value, exists := cfg.GetParam("radius"); exists {
+		radius, _ := toFloat64(value)
+		resolution = radius / float64(activeBits)
+	}
+
+	// Opposite points of the cycle must not share buckets
+	buckets := int64(math.Round(period / resolution))
+	if buckets < int64(2*activeBits) {
+		return &sensors.ConfigurationError{
+			Parameter: "resolution",
+			Value:     resolution,
+			Reason: fmt.Sprintf("period %g holds %d buckets but %d active bits need at least %d; decrease resolution or radius",
+				period, buckets, activeBits, 2*activeBits),
+		}
+	}
+
+	seed := cfg.GetIntParam("seed", defaultSeed)
+
+	s.applyConfig(cfg)
+	s.minValue = cfg.Range.Min
+	s.period = period
+	s.buckets = buckets
+	s.resolution = period / float64(buckets)
+	s.seed = uint64(seed)
+	return nil
+}
+
+// Encode converts a cyclic numeric value into an SDR
+func (s *PeriodicSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	value, err := toFloat64(input)
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	if !isFinite(value) {
+		return s.fail(input, "value must be finite")
+	}
+
+	phase := math.Mod(value-s.minValue, s.period)
+	if phase < 0 {
+		phase += s.period
+	}
+
+	bucket := int64(math.Floor(phase/s.resolution)) % s.buckets
+	keys := make([]int64, s.activeBitsCount())
+	for i := range keys {
+		keys[i] = (bucket + int64(i)) % s.buckets
+	}
+	return s.newSDR(hashKeys(s.seed, keys, s.config.SDRWidth))
+}
+
+// radius returns the value distance at which two encodings stop overlapping
+func (s *PeriodicSensor) radius() float64 {
+	return float64(s.activeBitsCount()) * s.resolution
+}
+
+// Validate checks if sensor configuration is valid
+func (s *PeriodicSensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	return s.config.IsValid()
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *PeriodicSensor) Metadata() sensors.SensorMetadata {
+	return s.metadata(map[string]interface{}{
+		"min_value":   s.minValue,
+		"period":      s.period,
+		"resolution":  s.resolution,
+		"buckets":     s.buckets,
+		"radius":      s.radius(),
+		"seed":        s.seed,
+		"periodic":    true,
+		"input_types": []string{"float64", "float32", "int", "int64", "int32", "uint"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *PeriodicSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	return &clone
+}
@@ -0,0 +1,109 @@
+package encoders
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// maxRDSEBucket bounds bucket indices so that bucket arithmetic never overflows int64
+const maxRDSEBucket = 1 << 52
+
+// RDSESensor is a Random Distributed Scalar Encoder for unbounded numeric
+// streams. Values are divided into resolution-sized buckets and each bucket
+// activates the hashed bits of itself and its following w-1 neighbours, so
+// values within w buckets of each other share bits without any configured range.
+type RDSESensor struct {
+	baseSensor
+	resolution float64 // Value width of a single bucket
+	seed       uint64  // Seed for the bucket hash
+}
+
+// rdseParams lists the custom parameters of the RDSE encoder
+var rdseParams = []sensors.ParamSpec{
+	seedParam("Seed for bucket hashing"),
+}
+
+// NewRDSESensor creates an unconfigured random distributed scalar encoder
+func NewRDSESensor() sensors.SensorInterface {
+	return &RDSESensor{
+		baseSensor: newBaseSensor("rdse", rdseParams...),
+		seed:       defaultSeed,
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// Uses Resolution from the config and ignores Range; CustomParams: seed (int),
+// silent_failure (bool)
+func (s *RDSESensor) Configure(config sensors.SensorConfig) error {
+	if err := config.ValidateResolution(); err != nil {
+		return err
+	}
+
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	seed := cfg.GetIntParam("seed", defaultSeed)
+
+	s.applyConfig(cfg)
+	s.resolution = cfg.Resolution
+	s.seed = uint64(seed)
+	return nil
+}
+
+// Encode converts an arbitrary finite numeric value into an SDR
+func (s *RDSESensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	if err := s.checkInput(input); err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	value, err := toFloat64(input)
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	if !isFinite(value) {
+		return s.fail(input, "value must be finite")
+	}
+
+	bucket := math.Floor(value / s.resolution)
+	if math.Abs(bucket) > maxRDSEBucket {
+		return s.fail(input, fmt.Sprintf("value %g exceeds the representable bucket range at resolution %g", value, s.resolution))
+	}
+
+	return s.newSDR(hashBits(s.seed, int64(bucket), s.activeBitsCount(), s.config.SDRWidth))
+}
+
+// Validate checks if sensor configuration is valid
+func (s *RDSESensor) Validate() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	return s.config.ValidateResolution()
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *RDSESensor) Metadata() sensors.SensorMetadata {
+	return s.metadata(map[string]interface{}{
+		"resolution":  s.resolution,
+		"seed":        s.seed,
+		"radius":      float64(s.activeBitsCount()) * s.resolution,
+		"bounded":     false,
+		"input_types": []string{"float64", "float32", "int", "int64", "int32", "uint"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *RDSESensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	return &clone
+}
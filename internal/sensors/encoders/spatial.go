@@ -0,0 +1,383 @@
+package encoders
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"sort"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// pngSignature identifies raw PNG input
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// SpatialSensor encodes 2D grids and grayscale images into topology
+// preserving SDRs. The binarized input is resampled onto a fixed grid of
+// cells, one SDR bit per cell, so neighbouring pixels map to neighbouring
+// bits. A cell is active when the majority of its pixels are set, which
+// makes isolated pixel noise vanish once cells span a few pixels each; the
+// active cells are then thinned or padded with partially set cells to reach
+// TargetSparsity. Inputs with too few set pixels produce sparser SDRs rather
+// than uninformative bits.
+type SpatialSensor struct {
+	baseSensor
+	gridWidth      int      // Number of cell columns
+	gridHeight     int      // Number of cell rows
+	binarization   string   // "threshold" or "local_contrast"
+	threshold      float64  // Fixed threshold for "threshold" binarization
+	contrastRadius int      // Neighbourhood radius for "local_contrast" binarization
+	contrastOffset float64  // Required margin above the local mean
+	tilesX         int      // Tile columns for EncodeTiles
+	tilesY         int      // Tile rows for EncodeTiles
+	seed           uint64   // Seed for deterministic cell priorities
+	priorities     []uint64 // Seeded tie-breaking priority per cell, shared read-only between clones
+}
+
+// spatialParams lists the custom parameters of the spatial encoder
+var spatialParams = []sensors.ParamSpec{
+	sensors.IntParam("grid_width", "Grid columns; defaults to the largest square fitting SDRWidth").WithMin(1),
+	sensors.IntParam("grid_height", "Grid rows; defaults to the largest square fitting SDRWidth").WithMin(1),
+	sensors.StringParam("binarization", "How cells are activated").WithDefault("threshold").WithEnum("threshold", "local_contrast"),
+	sensors.FloatParam("threshold", "Activation threshold of the threshold binarization").WithDefault(0.5),
+	sensors.IntParam("contrast_radius", "Neighbourhood radius of the local_contrast binarization").WithDefault(2).WithMin(1),
+	sensors.FloatParam("contrast_offset", "Offset above the local mean required by local_contrast").WithDefault(0.0),
+	sensors.IntParam("tiles_x", "Horizontal tiles sharing the active bits").WithDefault(1).WithMin(1),
+	sensors.IntParam("tiles_y", "Vertical tiles sharing the active bits").WithDefault(1).WithMin(1),
+	seedParam("Seed for tie breaking"),
+}
+
+// NewSpatialSensor creates an unconfigured spatial encoder
+func NewSpatialSensor() sensors.SensorInterface {
+	return &SpatialSensor{
+		baseSensor:   newBaseSensor("spatial", spatialParams...),
+		binarization: "threshold",
+		threshold:    0.5,
+		tilesX:       1,
+		tilesY:       1,
+		seed:         defaultSeed,
+	}
+}
+
+// Configure sets encoding parameters and validates configuration
+// CustomParams: grid_width, grid_height (int, default square fitting SDRWidth),
+// binarization ("threshold" or "local_contrast"), threshold (float64, default 0.5),
+// contrast_radius (int, default 2), contrast_offset (float64, default 0),
+// tiles_x, tiles_y (int, default 1), seed (int), silent_failure (bool)
+func (s *SpatialSensor) Configure(config sensors.SensorConfig) error {
+	cfg, err := s.prepareConfig(config)
+	if err != nil {
+		return err
+	}
+
+	side := int(math.Sqrt(float64(cfg.SDRWidth)))
+	gridWidth := cfg.GetIntParam("grid_width", side)
+	gridHeight := cfg.GetIntParam("grid_height", side)
+	if gridWidth*gridHeight > cfg.SDRWidth {
+		return &sensors.ConfigurationError{
+			Parameter: "grid_width",
+			Value:     fmt.Sprintf("%dx%d", gridWidth, gridHeight),
+			Reason:    fmt.Sprintf("grid must fit in SDR width %d", cfg.SDRWidth),
+		}
+	}
+
+	if activeBitsFor(cfg) > gridWidth*gridHeight {
+		return &sensors.ConfigurationError{
+			Parameter: "grid_width",
+			Value:     fmt.Sprintf("%dx%d", gridWidth, gridHeight),
+			Reason:    "grid has fewer cells than the required active bits",
+		}
+	}
+
+	binarization := cfg.GetStringParam("binarization", "threshold")
+	contrastRadius := cfg.GetIntParam("contrast_radius", 2)
+	tilesX := cfg.GetIntParam("tiles_x", 1)
+	tilesY := cfg.GetIntParam("tiles_y", 1)
+	seed := cfg.GetIntParam("seed", defaultSeed)
+
+	s.applyConfig(cfg)
+	s.gridWidth = gridWidth
+	s.gridHeight = gridHeight
+	s.binarization = binarization
+	s.threshold = cfg.GetFloatParam("threshold", 0.5)
+	s.contrastRadius = contrastRadius
+	s.contrastOffset = cfg.GetFloatParam("contrast_offset", 0.0)
+	s.tilesX = tilesX
+	s.tilesY = tilesY
+	s.seed = uint64(seed)
+	s.priorities = make([]uint64, gridWidth*gridHeight)
+	for cell := range s.priorities {
+		s.priorities[cell] = hashInt64(s.seed, int64(cell))
+	}
+	return nil
+}
+
+// Encode converts a [][]float64 grid, grayscale image.Image or PNG bytes into an SDR
+func (s *SpatialSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	grid, err := s.toGrid(input)
+	if err != nil {
+		return s.fail(input, err.Error())
+	}
+
+	return s.newSDR(s.encodeGrid(grid))
+}
+
+// EncodeTiles splits the input into tiles_x × tiles_y tiles and encodes each
+// tile separately, returning the SDRs in row-major tile order (FR-019)
+func (s *SpatialSensor) EncodeTiles(input interface{}) ([]sensors.SDR, error) {
+	if !s.configured {
+		return nil, s.notConfigured(input)
+	}
+
+	grid, err := s.toGrid(input)
+	if err != nil {
+		return s.failTiles(input, err.Error())
+	}
+
+	height, width := len(grid), len(grid[0])
+	if width < s.tilesX || height < s.tilesY {
+		return s.failTiles(input, fmt.Sprintf("%dx%d input is too small for %dx%d tiles",
+			width, height, s.tilesX, s.tilesY))
+	}
+
+	tiles := make([]sensors.SDR, 0, s.tilesX*s.tilesY)
+	for ty := 0; ty < s.tilesY; ty++ {
+		rowStart, rowEnd := ty*height/s.tilesY, (ty+1)*height/s.tilesY
+		for tx := 0; tx < s.tilesX; tx++ {
+			colStart, colEnd := tx*width/s.tilesX, (tx+1)*width/s.tilesX
+
+			tile := make([][]float64, rowEnd-rowStart)
+			for y := range tile {
+				tile[y] = grid[rowStart+y][colStart:colEnd]
+			}
+
+			encoded, err := s.newSDR(s.encodeGrid(tile))
+			if err != nil {
+				return nil, err
+			}
+			tiles = append(tiles, encoded)
+		}
+	}
+
+	return tiles, nil
+}
+
+// failTiles handles a failed tile encoding, returning one empty SDR per tile
+// in silent failure mode so callers can still index by tile
+func (s *SpatialSensor) failTiles(input interface{}, reason string) ([]sensors.SDR, error) {
+	empty, err := s.fail(input, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	tiles := make([]sensors.SDR, s.tilesX*s.tilesY)
+	for i := range tiles {
+		tiles[i] = empty
+	}
+	return tiles, nil
+}
+
+// toGrid converts supported inputs into a rectangular intensity grid
+func (s *SpatialSensor) toGrid(input interface{}) ([][]float64, error) {
+	switch v := input.(type) {
+	case [][]float64:
+		if len(v) == 0 || len(v[0]) == 0 {
+			return nil, fmt.Errorf("grid must not be empty")
+		}
+		if len(v)*len(v[0])*8 > maxInputSize {
+			return nil, fmt.Errorf("grid size exceeds 1MB limit")
+		}
+		for _, row := range v {
+			if len(row) != len(v[0]) {
+				return nil, fmt.Errorf("grid rows must have equal length")
+			}
+			for _, value := range row {
+				if !isFinite(value) {
+					return nil, fmt.Errorf("grid values must be finite")
+				}
+			}
+		}
+		return v, nil
+	case []byte:
+		if err := s.checkInput(v); err != nil {
+			return nil, err
+		}
+		if !bytes.HasPrefix(v, pngSignature) {
+			return nil, fmt.Errorf("byte input is not a PNG image")
+		}
+		// Check the header dimensions first: a small compressed file can
+		// decode into an image far larger than the input limit
+		header, err := png.DecodeConfig(bytes.NewReader(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid PNG image: %v", err)
+		}
+		if header.Width*header.Height > maxInputSize {
+			return nil, fmt.Errorf("image size exceeds 1MB limit")
+		}
+		img, err := png.Decode(bytes.NewReader(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid PNG image: %v", err)
+		}
+		return imageToGrid(img)
+	case image.Image:
+		return imageToGrid(v)
+	case nil:
+		return nil, fmt.Errorf("input cannot be nil")
+	default:
+		return nil, fmt.Errorf("unsupported spatial input type %T", input)
+	}
+}
+
+// imageToGrid converts an image into grayscale intensities in [0, 1]
+func imageToGrid(img image.Image) ([][]float64, error) {
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return nil, fmt.Errorf("image must not be empty")
+	}
+	if bounds.Dx()*bounds.Dy() > maxInputSize {
+		return nil, fmt.Errorf("image size exceeds 1MB limit")
+	}
+
+	grid := make([][]float64, bounds.Dy())
+	for y := range grid {
+		grid[y] = make([]float64, bounds.Dx())
+		for x := range grid[y] {
+			gray := color.Gray16Model.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray16)
+			grid[y][x] = float64(gray.Y) / math.MaxUint16
+		}
+	}
+	return grid, nil
+}
+
+// binarize marks pixels as set according to the configured binarization
+func (s *SpatialSensor) binarize(grid [][]float64) [][]bool {
+	height, width := len(grid), len(grid[0])
+	binary := make([][]bool, height)
+
+	if s.binarization == "threshold" {
+		for y := range grid {
+			binary[y] = make([]bool, width)
+			for x, value := range grid[y] {
+				binary[y][x] = value >= s.threshold
+			}
+		}
+		return binary
+	}
+
+	// Local contrast: compare each pixel with the mean of its neighbourhood,
+	// using a summed-area table for constant time window sums
+	sums := make([][]float64, height+1)
+	for y := range sums {
+		sums[y] = make([]float64, width+1)
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			sums[y+1][x+1] = grid[y][x] + sums[y][x+1] + sums[y+1][x] - sums[y][x]
+		}
+	}
+
+	r := s.contrastRadius
+	for y := 0; y < height; y++ {
+		binary[y] = make([]bool, width)
+		y0, y1 := max(0, y-r), min(height, y+r+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := max(0, x-r), min(width, x+r+1)
+			area := float64((y1 - y0) * (x1 - x0))
+			mean := (sums[y1][x1] - sums[y0][x1] - sums[y1][x0] + sums[y0][x0]) / area
+			binary[y][x] = grid[y][x] > mean+s.contrastOffset
+		}
+	}
+	return binary
+}
+
+// encodeGrid binarizes the grid, pools it onto the cell layout and selects
+// exactly the target number of active cells
+func (s *SpatialSensor) encodeGrid(grid [][]float64) []int {
+	binary := s.binarize(grid)
+	height, width := len(binary), len(binary[0])
+
+	cells := s.gridWidth * s.gridHeight
+	density := make([]float64, cells)
+	for cy := 0; cy < s.gridHeight; cy++ {
+		y0 := cy * height / s.gridHeight
+		y1 := max(y0+1, (cy+1)*height/s.gridHeight)
+		for cx := 0; cx < s.gridWidth; cx++ {
+			x0 := cx * width / s.gridWidth
+			x1 := max(x0+1, (cx+1)*width/s.gridWidth)
+
+			set := 0
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					if binary[y][x] {
+						set++
+					}
+				}
+			}
+			density[cy*s.gridWidth+cx] = float64(set) / float64((y1-y0)*(x1-x0))
+		}
+	}
+
+	// Majority cells come first in a fixed seeded order so that thinning keeps
+	// the same cells when noise does not change the majority; the remaining
+	// cells follow by density when padding is needed. Cells without any set
+	// pixel carry no information and are never padded in.
+	var majority, partial []int
+	for cell, value := range density {
+		if value > 0.5 {
+			majority = append(majority, cell)
+		} else if value > 0 {
+			partial = append(partial, cell)
+		}
+	}
+
+	activeBits := s.activeBitsCount()
+	sort.Slice(majority, func(i, j int) bool {
+		return s.priorities[majority[i]] < s.priorities[majority[j]]
+	})
+	if len(majority) >= activeBits {
+		return majority[:activeBits]
+	}
+
+	sort.Slice(partial, func(i, j int) bool {
+		a, b := partial[i], partial[j]
+		if density[a] != density[b] {
+			return density[a] > density[b]
+		}
+		return s.priorities[a] < s.priorities[b]
+	})
+	if padding := activeBits - len(majority); len(partial) > padding {
+		partial = partial[:padding]
+	}
+	return append(majority, partial...)
+}
+
+// Validate checks if sensor configuration is valid
+func (s *SpatialSensor) Validate() error {
+	return s.validate()
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *SpatialSensor) Metadata() sensors.SensorMetadata {
+	return s.metadata(map[string]interface{}{
+		"grid_width":   s.gridWidth,
+		"grid_height":  s.gridHeight,
+		"binarization": s.binarization,
+		"tiles":        s.tilesX * s.tilesY,
+		"multi_sdr":    s.tilesX*s.tilesY > 1,
+		"input_types":  []string{"[][]float64", "image.Image", "[]byte (PNG)"},
+	})
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *SpatialSensor) Clone() sensors.SensorInterface {
+	clone := *s
+	clone.baseSensor = s.clone()
+	return &clone
+}
@@ -0,0 +1,316 @@
+package encoders
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+)
+
+// structTag is the struct tag read by StructSensor
+const structTag = "htm"
+
+// structTypeAliases maps short tag names to registered sensor types
+var structTypeAliases = map[string]string{
+	"category": "categorical",
+	"time":     "datetime",
+	"geo":      "geospatial",
+}
+
+// structPlan is the parsed field layout of a Go struct type
+type structPlan struct {
+	fields []CompositeField
+	err    error
+}
+
+// structPlans caches the plan of every struct type seen so far
+var structPlans sync.Map // reflect.Type -> *structPlan
+
+// StructSensor encodes values of a Go struct type (FR-001). Fields tagged
+// with `htm:"<type>[,key=value...]"` are encoded by a child sensor of that
+// registered type and concatenated like a CompositeSensor, in field order.
+//
+// Tag options min and max set the child Range, resolution, width and
+// sparsity set Resolution, SDRWidth and TargetSparsity, and all other
+// options become CustomParams of the type the child sensor declares for
+// them; list values are written a|b|c, so categories=red is a one-element
+// list. Fields tagged "-" or without a tag are ignored. The tag layout of a
+// type is parsed once and shared by all sensors of that type.
+type StructSensor struct {
+	composite *CompositeSensor
+	goType    reflect.Type
+}
+
+// NewStructSensor creates an unconfigured encoder for the type of sample,
+// which may be a struct value or a pointer to one
+func NewStructSensor(registry *sensors.Registry, sample interface{}) *StructSensor {
+	goType := reflect.TypeOf(sample)
+	for goType != nil && goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+
+	plan := planFor(goType)
+	composite := NewCompositeSensor(registry, plan.fields...)
+	composite.sensorType = "struct"
+
+	return &StructSensor{
+		composite: composite,
+		goType:    goType,
+	}
+}
+
+// StructFactory returns a factory for registering a Go struct type as a
+// sensor type of its own
+func StructFactory(registry *sensors.Registry, sample interface{}) sensors.SensorFactory {
+	return func() sensors.SensorInterface {
+		return NewStructSensor(registry, sample)
+	}
+}
+
+// planFor returns the cached plan for a struct type, parsing it on first use
+func planFor(goType reflect.Type) *structPlan {
+	if goType == nil {
+		return &structPlan{err: fmt.Errorf("sample cannot be nil")}
+	}
+
+	if cached, ok := structPlans.Load(goType); ok {
+		return cached.(*structPlan)
+	}
+
+	fields, err := parseStructFields(goType)
+	plan, _ := structPlans.LoadOrStore(goType, &structPlan{fields: fields, err: err})
+	return plan.(*structPlan)
+}
+
+// parseStructFields builds the child field layout from the htm tags of a struct type
+func parseStructFields(goType reflect.Type) ([]CompositeField, error) {
+	if goType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("sample must be a struct, got %s", goType)
+	}
+
+	var fields []CompositeField
+	for i := 0; i < goType.NumField(); i++ {
+		field := goType.Field(i)
+		tag, tagged := field.Tag.Lookup(structTag)
+		if !tagged || tag == "-" {
+			continue
+		}
+
+		if !field.IsExported() {
+			return nil, fmt.Errorf("field %s: tagged field must be exported", field.Name)
+		}
+
+		sensorType, config, err := parseStructTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", field.Name, err)
+		}
+
+		fields = append(fields, CompositeField{Name: field.Name, Type: sensorType, Config: *config})
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("type %s has no fields tagged %q", goType, structTag)
+	}
+
+	return fields, nil
+}
+
+// parseStructTag splits an htm tag into the child sensor type and configuration
+func parseStructTag(tag string) (string, *sensors.SensorConfig, error) {
+	parts := strings.Split(tag, ",")
+	sensorType := strings.TrimSpace(parts[0])
+	if sensorType == "" {
+		return "", nil, fmt.Errorf("tag %q has no sensor type", tag)
+	}
+	if alias, ok := structTypeAliases[sensorType]; ok {
+		sensorType = alias
+	}
+
+	config := sensors.NewSensorConfig()
+	var minValue, maxValue *float64
+	for _, option := range parts[1:] {
+		key, raw, found := strings.Cut(strings.TrimSpace(option), "=")
+		if !found || key == "" {
+			return "", nil, fmt.Errorf("option %q must have the form key=value", option)
+		}
+
+		switch key {
+		case "min", "max", "resolution", "sparsity":
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return "", nil, fmt.Errorf("option %s: %q is not a number", key, raw)
+			}
+			switch key {
+			case "min":
+				minValue = &number
+			case "max":
+				maxValue = &number
+			case "resolution":
+				config.Resolution = number
+			case "sparsity":
+				config.TargetSparsity = number
+			}
+		case "width":
+			width, err := strconv.Atoi(raw)
+			if err != nil {
+				return "", nil, fmt.Errorf("option width: %q is not an integer", raw)
+			}
+			config.SDRWidth = width
+		default:
+			// Typed against the child schema in Configure
+			config.SetParam(key, raw)
+		}
+	}
+
+	if minValue != nil {
+		config.Range.Min = *minValue
+	}
+	if maxValue != nil {
+		config.Range.Max = *maxValue
+	}
+
+	return sensorType, config, nil
+}
+
+// parseTagValue converts a tag option value to the declared parameter type
+func parseTagValue(spec sensors.ParamSpec, raw string) (interface{}, error) {
+	switch spec.Type {
+	case sensors.ParamBool:
+		return strconv.ParseBool(raw)
+	case sensors.ParamInt:
+		return strconv.Atoi(raw)
+	case sensors.ParamFloat:
+		return strconv.ParseFloat(raw, 64)
+	case sensors.ParamStringList:
+		return strings.Split(raw, "|"), nil
+	case sensors.ParamFloatList:
+		items := strings.Split(raw, "|")
+		numbers := make([]float64, len(items))
+		for i, item := range items {
+			number, err := strconv.ParseFloat(item, 64)
+			if err != nil {
+				return nil, err
+			}
+			numbers[i] = number
+		}
+		return numbers, nil
+	case sensors.ParamObject:
+		return nil, fmt.Errorf("object parameters cannot be set in a struct tag")
+	default:
+		return raw, nil
+	}
+}
+
+// typedFields converts the tag options of every field to the parameter
+// types its child sensor declares; options the child does not declare are
+// kept as strings for the child to reject
+func (s *StructSensor) typedFields(fields []CompositeField) ([]CompositeField, error) {
+	typed := make([]CompositeField, len(fields))
+	for i, field := range fields {
+		typed[i] = field
+		typed[i].Config = *field.Config.Clone()
+
+		schema, err := s.composite.registry.Parameters(field.Type)
+		if err != nil {
+			continue // The composite reports unknown sensor types
+		}
+
+		for key, value := range field.Config.CustomParams {
+			spec, declared := schema.Lookup(key)
+			if !declared {
+				continue
+			}
+
+			parsed, err := parseTagValue(spec, value.(string))
+			if err != nil {
+				return nil, &sensors.ConfigurationError{
+					Parameter: "fields." + field.Name,
+					Value:     value,
+					Reason:    fmt.Sprintf("option %s: %v", key, err),
+				}
+			}
+			typed[i].Config.SetParam(key, parsed)
+		}
+	}
+	return typed, nil
+}
+
+// Configure creates and configures the child sensors of the tagged fields
+// SDRWidth and TargetSparsity are derived from the children; CustomParams:
+// silent_failure (bool)
+func (s *StructSensor) Configure(config sensors.SensorConfig) error {
+	plan := planFor(s.goType)
+	if plan.err != nil {
+		return &sensors.ConfigurationError{
+			Parameter: "type",
+			Value:     fmt.Sprint(s.goType),
+			Reason:    plan.err.Error(),
+		}
+	}
+
+	if s.composite.registry != nil {
+		fields, err := s.typedFields(plan.fields)
+		if err != nil {
+			return err
+		}
+		s.composite.fields = fields
+	}
+
+	if err := s.composite.Configure(config); err != nil {
+		return err
+	}
+
+	s.composite.identifyChildren(map[string]interface{}{"go_type": fmt.Sprint(s.goType)})
+	return nil
+}
+
+// Encode encodes a value or pointer of the sensor's struct type
+func (s *StructSensor) Encode(input interface{}) (sensors.SDR, error) {
+	if !s.composite.configured {
+		return nil, s.composite.notConfigured(input)
+	}
+
+	value := reflect.ValueOf(input)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if !value.IsValid() || value.Type() != s.goType {
+		return s.composite.fail(input, fmt.Sprintf("expected input of type %s, got %T", s.goType, input))
+	}
+
+	return s.composite.Encode(value.Interface())
+}
+
+// Layout returns the segment of every tagged field in field order
+func (s *StructSensor) Layout() []CompositeSegment {
+	return s.composite.Layout()
+}
+
+// Validate checks if sensor configuration is valid
+func (s *StructSensor) Validate() error {
+	return s.composite.Validate()
+}
+
+// Identity returns the sensor identity as of the last successful Configure
+func (s *StructSensor) Identity() sensors.SensorIdentity {
+	return s.composite.Identity()
+}
+
+// Metadata returns sensor characteristics and capabilities
+func (s *StructSensor) Metadata() sensors.SensorMetadata {
+	metadata := s.composite.Metadata()
+	metadata.Capabilities["go_type"] = fmt.Sprint(s.goType)
+	metadata.Capabilities["input_types"] = []string{fmt.Sprint(s.goType), "*" + fmt.Sprint(s.goType)}
+	return metadata
+}
+
+// Clone creates a new sensor instance with same configuration
+func (s *StructSensor) Clone() sensors.SensorInterface {
+	return &StructSensor{
+		composite: s.composite.Clone().(*CompositeSensor),
+		goType:    s.goType,
+	}
+}
@@ -0,0 +1,130 @@
+package sensors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// canonicalConfig is the serialized form of a SensorConfig
+type canonicalConfig struct {
+	SDRWidth       int                        `json:"sdr_width"`
+	TargetSparsity float64                    `json:"target_sparsity"`
+	Resolution     float64                    `json:"resolution"`
+	Range          *canonicalRange            `json:"range,omitempty"`
+	Params         map[string]json.RawMessage `json:"params"`
+}
+
+// nonEncodingParams lists parameters that change how a sensor reports
+// errors but not the SDRs it produces, so they are left out of the
+// canonical form and sensors differing only in them fingerprint alike
+var nonEncodingParams = map[string]bool{
+	"silent_failure": true,
+}
+
+// canonicalRange is the serialized form of a Range
+type canonicalRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Canonical returns a deterministic JSON serialization of the configuration.
+// Object keys are sorted and numbers use their shortest representation, so
+// equal configurations serialize to identical bytes regardless of map order
+// or whether a whole number was given as int or float64. Parameters that
+// do not affect encodings, such as silent_failure, are omitted. Parameters
+// that cannot be serialized are reported as a ConfigurationError.
+func (c *SensorConfig) Canonical() ([]byte, error) {
+	params := make(map[string]json.RawMessage, len(c.CustomParams))
+	for key, value := range c.CustomParams {
+		if nonEncodingParams[key] {
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, &ConfigurationError{
+				Parameter: key,
+				Value:     value,
+				Reason:    "cannot be serialized: " + err.Error(),
+			}
+		}
+		params[key] = encoded
+	}
+
+	canonical := canonicalConfig{
+		SDRWidth:       c.SDRWidth,
+		TargetSparsity: c.TargetSparsity,
+		Resolution:     c.Resolution,
+		Params:         params,
+	}
+	if c.Range != nil {
+		canonical.Range = &canonicalRange{Min: c.Range.Min, Max: c.Range.Max}
+	}
+
+	return json.Marshal(canonical)
+}
+
+// Fingerprint returns the SHA-256 content hash of the canonical configuration
+func (c *SensorConfig) Fingerprint() (string, error) {
+	canonical, err := c.Canonical()
+	if err != nil {
+		return "", err
+	}
+	return contentHash(canonical), nil
+}
+
+// SensorIdentity lists the configuration that determines a sensor's
+// encodings. Two stateless sensors with equal identities produce identical
+// SDRs for equal inputs (FR-006), so comparing fingerprints detects
+// mismatched encoders, e.g. between training and inference. The identity
+// covers configuration only: stateful sensors (SensorMetadata.Stateful),
+// such as the adaptive and delta encoders, additionally depend on the
+// inputs they have seen, which the fingerprint does not include.
+type SensorIdentity struct {
+	Type       string                 // Sensor type
+	Version    string                 // Version of the encoding algorithm
+	Config     *SensorConfig          // Configuration with parameter defaults, including the seed, filled in
+	Components map[string]interface{} // Settings outside the SensorConfig, e.g. child sensor fingerprints
+}
+
+// Canonical returns a deterministic JSON serialization of the identity
+func (id SensorIdentity) Canonical() ([]byte, error) {
+	config := NewSensorConfig()
+	if id.Config != nil {
+		config = id.Config
+	}
+
+	canonicalConfig, err := config.Canonical()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Type       string                 `json:"type"`
+		Version    string                 `json:"version"`
+		Config     json.RawMessage        `json:"config"`
+		Components map[string]interface{} `json:"components,omitempty"`
+	}{id.Type, id.Version, canonicalConfig, id.Components})
+}
+
+// Fingerprint returns the SHA-256 content hash of the canonical identity
+func (id SensorIdentity) Fingerprint() (string, error) {
+	canonical, err := id.Canonical()
+	if err != nil {
+		return "", err
+	}
+	return contentHash(canonical), nil
+}
+
+// Identifiable is implemented by sensors that expose the identity their
+// fingerprint is computed from
+type Identifiable interface {
+	// Identity returns the sensor's identity as of its last successful Configure
+	Identity() SensorIdentity
+}
+
+// contentHash returns the hex-encoded SHA-256 of data
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -28,6 +28,20 @@ type SensorInterface interface {
 	Clone() SensorInterface
 }
 
+// Decoder is implemented by sensors that can reconstruct input values from SDRs
+type Decoder interface {
+	// Decode returns up to maxCandidates values ranked by confidence whose
+	// encodings are present in the SDR, which may be noisy or a union of
+	// several encodings; maxCandidates <= 0 returns every candidate found
+	Decode(sdr SDR, maxCandidates int) ([]DecodeCandidate, error)
+}
+
+// DecodeCandidate is a reconstructed input value with its confidence
+type DecodeCandidate struct {
+	Value      interface{} // Value in input units
+	Confidence float64     // Fraction of the value's active bits found in the SDR (0.0-1.0)
+}
+
 // SDR interface wraps the internal SDR implementation for public API
 type SDR interface {
 	// Width returns the total number of bits in the representation
@@ -50,14 +64,22 @@ type SDR interface {
 
 	// String returns a string representation for debugging
 	String() string
+
+	// Fingerprint returns the configuration fingerprint of the sensor that
+	// produced the SDR, or an empty string if unknown
+	Fingerprint() string
 }
 
 // SensorMetadata provides information about sensor characteristics
 type SensorMetadata struct {
 	Type         string                 // Sensor type identifier ("numeric", "categorical", etc.)
+	Version      string                 // Version of the encoding algorithm
+	Fingerprint  string                 // Configuration fingerprint, empty until configured; excludes learned state
+	Stateful     bool                   // Encodings depend on previously encoded inputs, not only on the configuration
 	SDRWidth     int                    // Configured SDR width
 	Sparsity     float64                // Target sparsity
 	MaxInputSize int                    // Maximum input size in bytes (1MB limit)
+	Parameters   ParamSchema            // Custom parameters accepted by Configure
 	Capabilities map[string]interface{} // Type-specific capabilities
 }
 
@@ -90,6 +112,16 @@ func (e *ConfigurationError) Error() string {
 	return "configuration error for " + e.Parameter + ": " + e.Reason
 }
 
+// DecodingError represents an error while reconstructing values from an SDR
+type DecodingError struct {
+	SensorType string
+	Reason     string
+}
+
+func (e *DecodingError) Error() string {
+	return "decoding error: " + e.Reason
+}
+
 // ValidationError represents an error during sensor validation
 type ValidationError struct {
 	Component string
@@ -102,7 +134,8 @@ func (e *ValidationError) Error() string {
 
 // SDRWrapper wraps the internal SDR implementation to conform to the public interface
 type SDRWrapper struct {
-	internal *sdr.SDR
+	internal    *sdr.SDR
+	fingerprint string // Fingerprint of the producing sensor's configuration
 }
 
 // NewSDRWrapper creates a wrapper for internal SDR
@@ -110,6 +143,12 @@ func NewSDRWrapper(internalSDR *sdr.SDR) SDR {
 	return &SDRWrapper{internal: internalSDR}
 }
 
+// NewFingerprintedSDR creates a wrapper for internal SDR tagged with the
+// configuration fingerprint of the sensor that produced it
+func NewFingerprintedSDR(internalSDR *sdr.SDR, fingerprint string) SDR {
+	return &SDRWrapper{internal: internalSDR, fingerprint: fingerprint}
+}
+
 // Width returns the total number of bits in the representation
 func (w *SDRWrapper) Width() int {
 	return w.internal.Width()
@@ -151,6 +190,11 @@ func (w *SDRWrapper) String() string {
 	return w.internal.String()
 }
 
+// Fingerprint returns the configuration fingerprint of the producing sensor
+func (w *SDRWrapper) Fingerprint() string {
+	return w.fingerprint
+}
+
 // GetInternalSDR returns the internal SDR for package-internal use
 func (w *SDRWrapper) GetInternalSDR() *sdr.SDR {
 	return w.internal
@@ -198,10 +242,16 @@ func estimateInputSize(input interface{}) int {
 		return len(v) * 8
 	case []float32:
 		return len(v) * 4
+	case []int16:
+		return len(v) * 2
 	case []int:
 		return len(v) * 8 // Assuming 64-bit ints
 	case []int64:
 		return len(v) * 8
+	case []uint32:
+		return len(v) * 4
+	case []uint64:
+		return len(v) * 8
 	default:
 		// Conservative estimate for unknown types
 		return 100
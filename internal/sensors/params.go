@@ -0,0 +1,349 @@
+package sensors
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ParamType names the Go type a custom parameter is coerced to
+type ParamType string
+
+// Supported custom parameter types
+const (
+	ParamInt        ParamType = "int"
+	ParamFloat      ParamType = "float64"
+	ParamBool       ParamType = "bool"
+	ParamString     ParamType = "string"
+	ParamStringList ParamType = "[]string"
+	ParamFloatList  ParamType = "[]float64"
+	ParamObject     ParamType = "object" // Any map, e.g. map[string][]string
+)
+
+// ParamSpec describes one custom parameter accepted by a sensor type
+type ParamSpec struct {
+	Name        string      `json:"name"`
+	Type        ParamType   `json:"type"`
+	Description string      `json:"description"`
+	Default     interface{} `json:"default,omitempty"` // Value used when absent; nil if derived from other settings
+	Required    bool        `json:"required,omitempty"`
+	Min         *float64    `json:"min,omitempty"`  // Inclusive lower bound for numbers
+	Max         *float64    `json:"max,omitempty"`  // Inclusive upper bound for numbers
+	Enum        []string    `json:"enum,omitempty"` // Allowed values for strings
+}
+
+// IntParam declares an int parameter
+func IntParam(name, description string) ParamSpec {
+	return ParamSpec{Name: name, Type: ParamInt, Description: description}
+}
+
+// FloatParam declares a float64 parameter
+func FloatParam(name, description string) ParamSpec {
+	return ParamSpec{Name: name, Type: ParamFloat, Description: description}
+}
+
+// BoolParam declares a bool parameter
+func BoolParam(name, description string) ParamSpec {
+	return ParamSpec{Name: name, Type: ParamBool, Description: description}
+}
+
+// StringParam declares a string parameter
+func StringParam(name, description string) ParamSpec {
+	return ParamSpec{Name: name, Type: ParamString, Description: description}
+}
+
+// StringListParam declares a []string parameter
+func StringListParam(name, description string) ParamSpec {
+	return ParamSpec{Name: name, Type: ParamStringList, Description: description}
+}
+
+// FloatListParam declares a []float64 parameter
+func FloatListParam(name, description string) ParamSpec {
+	return ParamSpec{Name: name, Type: ParamFloatList, Description: description}
+}
+
+// ObjectParam declares a map parameter that the sensor interprets itself
+func ObjectParam(name, description string) ParamSpec {
+	return ParamSpec{Name: name, Type: ParamObject, Description: description}
+}
+
+// WithDefault documents the value used when the parameter is absent
+func (p ParamSpec) WithDefault(value interface{}) ParamSpec {
+	p.Default = value
+	return p
+}
+
+// WithMin sets an inclusive lower bound for a numeric parameter
+func (p ParamSpec) WithMin(min float64) ParamSpec {
+	p.Min = &min
+	return p
+}
+
+// WithMax sets an inclusive upper bound for a numeric parameter
+func (p ParamSpec) WithMax(max float64) ParamSpec {
+	p.Max = &max
+	return p
+}
+
+// WithEnum restricts a string parameter to the given values
+func (p ParamSpec) WithEnum(values ...string) ParamSpec {
+	p.Enum = values
+	return p
+}
+
+// WithRequired marks the parameter as mandatory
+func (p ParamSpec) WithRequired() ParamSpec {
+	p.Required = true
+	return p
+}
+
+// ParamSchema lists the custom parameters a sensor type accepts
+type ParamSchema []ParamSpec
+
+// reservedParams are accepted by every sensor whether or not its schema
+// declares them, since wrappers such as the composite sensor set them on
+// their children
+var reservedParams = map[string]bool{
+	"silent_failure": true,
+}
+
+// Lookup returns the spec of a parameter by name
+func (s ParamSchema) Lookup(name string) (ParamSpec, bool) {
+	for _, spec := range s {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return ParamSpec{}, false
+}
+
+// Apply coerces every declared parameter of the configuration to its
+// declared type in place and checks requirements, bounds and allowed
+// values. Whole numbers decoded from JSON as float64 become int, ints
+// become float64 and JSON-decoded lists become typed slices. Parameters
+// that are neither declared nor reserved are rejected.
+func (s ParamSchema) Apply(config *SensorConfig) error {
+	names := make([]string, 0, len(config.CustomParams))
+	for name := range config.CustomParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, declared := s.Lookup(name); !declared && !reservedParams[name] {
+			return &ConfigurationError{
+				Parameter: name,
+				Value:     config.CustomParams[name],
+				Reason:    "unknown parameter",
+			}
+		}
+	}
+
+	for _, spec := range s {
+		value, exists := config.CustomParams[spec.Name]
+		if !exists || value == nil {
+			if spec.Required {
+				return &ConfigurationError{
+					Parameter: spec.Name,
+					Value:     nil,
+					Reason:    "is required",
+				}
+			}
+			continue
+		}
+
+		coerced, err := spec.coerce(value)
+		if err != nil {
+			return &ConfigurationError{
+				Parameter: spec.Name,
+				Value:     value,
+				Reason:    err.Error(),
+			}
+		}
+
+		if err := spec.check(coerced); err != nil {
+			return &ConfigurationError{
+				Parameter: spec.Name,
+				Value:     coerced,
+				Reason:    err.Error(),
+			}
+		}
+
+		config.CustomParams[spec.Name] = coerced
+	}
+	return nil
+}
+
+// FillDefaults sets every absent parameter that has a documented default,
+// so that configurations relying on defaults and configurations spelling
+// them out compare equal
+func (s ParamSchema) FillDefaults(config *SensorConfig) {
+	if config.CustomParams == nil {
+		config.CustomParams = make(map[string]interface{})
+	}
+
+	for _, spec := range s {
+		if spec.Default == nil {
+			continue
+		}
+		if value, exists := config.CustomParams[spec.Name]; !exists || value == nil {
+			config.SetParam(spec.Name, spec.Default)
+		}
+	}
+}
+
+// coerce converts a raw parameter value to the declared type
+func (p ParamSpec) coerce(value interface{}) (interface{}, error) {
+	switch p.Type {
+	case ParamInt:
+		number, ok := paramNumber(value)
+		if !ok || number != math.Trunc(number) || math.Abs(number) > 1<<53 {
+			return nil, fmt.Errorf("expected an integer, got %v (%T)", value, value)
+		}
+		return int(number), nil
+	case ParamFloat:
+		number, ok := paramNumber(value)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, fmt.Errorf("expected a finite number, got %v (%T)", value, value)
+		}
+		return number, nil
+	case ParamBool:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("expected a bool, got %T", value)
+	case ParamString:
+		if str, ok := value.(string); ok {
+			return str, nil
+		}
+		return nil, fmt.Errorf("expected a string, got %T", value)
+	case ParamStringList:
+		return coerceStringList(value)
+	case ParamFloatList:
+		return coerceFloatList(value)
+	case ParamObject:
+		if reflect.ValueOf(value).Kind() == reflect.Map {
+			return value, nil
+		}
+		return nil, fmt.Errorf("expected an object, got %T", value)
+	default:
+		return value, nil
+	}
+}
+
+// check validates a coerced value against bounds and allowed values
+func (p ParamSpec) check(value interface{}) error {
+	var number float64
+	switch v := value.(type) {
+	case int:
+		number = float64(v)
+	case float64:
+		number = v
+	case string:
+		if len(p.Enum) == 0 {
+			return nil
+		}
+		for _, allowed := range p.Enum {
+			if v == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of '%s'", strings.Join(p.Enum, "', '"))
+	default:
+		return nil
+	}
+
+	if p.Min != nil && number < *p.Min {
+		return fmt.Errorf("must be at least %g", *p.Min)
+	}
+	if p.Max != nil && number > *p.Max {
+		return fmt.Errorf("must be at most %g", *p.Max)
+	}
+	return nil
+}
+
+// paramNumber converts any Go or JSON numeric value to float64
+func paramNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// coerceStringList converts any slice of strings, e.g. a JSON-decoded
+// []interface{}, into []string
+func coerceStringList(value interface{}) ([]string, error) {
+	list := reflect.ValueOf(value)
+	if list.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a list of strings, got %T", value)
+	}
+
+	result := make([]string, list.Len())
+	for i := range result {
+		str, ok := list.Index(i).Interface().(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a list of strings, found element of type %T", list.Index(i).Interface())
+		}
+		result[i] = str
+	}
+	return result, nil
+}
+
+// coerceFloatList converts any slice of numbers, e.g. a JSON-decoded
+// []interface{} or an []int, into []float64
+func coerceFloatList(value interface{}) ([]float64, error) {
+	list := reflect.ValueOf(value)
+	if list.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a list of numbers, got %T", value)
+	}
+
+	result := make([]float64, list.Len())
+	for i := range result {
+		number, ok := paramNumber(list.Index(i).Interface())
+		if !ok {
+			return nil, fmt.Errorf("expected a list of numbers, found element of type %T", list.Index(i).Interface())
+		}
+		result[i] = number
+	}
+	return result, nil
+}
+
+// Parameters returns the parameter schema a registered sensor type declares
+func (r *Registry) Parameters(sensorType string) (ParamSchema, error) {
+	sensor, err := r.Create(sensorType)
+	if err != nil {
+		return nil, err
+	}
+
+	return sensor.Metadata().Parameters, nil
+}
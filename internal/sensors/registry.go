@@ -4,70 +4,217 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 )
 
-// Registry manages sensor factory functions and provides sensor creation
+// Registry manages sensor factory functions and provides sensor creation.
+// A sensor type is registered either once without a version or any number
+// of times with distinct versions, which stay available side by side.
+// Lookups take a registry key: a bare sensor type resolves to the newest
+// version, "numeric@2" to the newest 2.x.y, "numeric@2.1" to the newest
+// 2.1.y and "numeric@2.1.0" to exactly that version.
 type Registry struct {
-	factories map[string]SensorFactory
-	mutex     sync.RWMutex // Protects concurrent access to factories map
+	factories map[string]SensorFactory      // Factory per sensor type registered without a version
+	versions  map[string][]versionedFactory // Factories per versioned sensor type, oldest first
+	mutex     sync.RWMutex                  // Protects concurrent access to factories and versions
 }
 
 // NewRegistry creates a new sensor registry
 func NewRegistry() *Registry {
 	return &Registry{
 		factories: make(map[string]SensorFactory),
+		versions:  make(map[string][]versionedFactory),
 	}
 }
 
 // Register adds a sensor factory function for the specified type
 func (r *Registry) Register(sensorType string, factory SensorFactory) error {
+	return r.register(sensorType, "", factory)
+}
+
+// RegisterVersion adds a sensor factory tagged with the MAJOR.MINOR.PATCH
+// version of its encoding algorithm. Earlier versions of the same type
+// remain available under their own version.
+func (r *Registry) RegisterVersion(sensorType, version string, factory SensorFactory) error {
+	if version == "" {
+		return errors.New("sensor version cannot be empty")
+	}
+
+	return r.register(sensorType, version, factory)
+}
+
+// register adds a factory and its optional version tag in one step
+func (r *Registry) register(sensorType, version string, factory SensorFactory) error {
 	if sensorType == "" {
 		return errors.New("sensor type cannot be empty")
 	}
 
+	if strings.Contains(sensorType, VersionSeparator) {
+		return fmt.Errorf("sensor type '%s' cannot contain '%s'", sensorType, VersionSeparator)
+	}
+
 	if factory == nil {
 		return errors.New("factory function cannot be nil")
 	}
 
+	var parsed sensorVersion
+	if version != "" {
+		var count int
+		var err error
+		parsed, count, err = parseVersion(version)
+		if err != nil {
+			return err
+		}
+		if count != len(parsed) {
+			return fmt.Errorf("invalid version '%s': expected MAJOR.MINOR.PATCH", version)
+		}
+	}
+
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
 	// Check for duplicate registration
-	if _, exists := r.factories[sensorType]; exists {
+	registered := r.versions[sensorType]
+	if _, exists := r.factories[sensorType]; exists || (version == "" && len(registered) > 0) {
 		return fmt.Errorf("sensor type '%s' is already registered", sensorType)
 	}
 
-	r.factories[sensorType] = factory
+	if version == "" {
+		r.factories[sensorType] = factory
+		return nil
+	}
+
+	position := len(registered)
+	for i, entry := range registered {
+		if entry.parsed == parsed {
+			return fmt.Errorf("version '%s' of sensor type '%s' is already registered", version, sensorType)
+		}
+		if parsed.less(entry.parsed) {
+			position = i
+			break
+		}
+	}
+
+	registered = append(registered, versionedFactory{})
+	copy(registered[position+1:], registered[position:])
+	registered[position] = versionedFactory{version: version, parsed: parsed, factory: factory}
+	r.versions[sensorType] = registered
 	return nil
 }
 
-// Create creates a new sensor instance of the specified type
+// resolve finds the factory a registry key refers to; callers must hold the lock
+func (r *Registry) resolve(key string) (string, versionedFactory, error) {
+	sensorType, pin := SplitSensorKey(key)
+	if pin == "" && strings.Contains(key, VersionSeparator) {
+		return "", versionedFactory{}, fmt.Errorf("sensor key '%s' has an empty version", key)
+	}
+
+	if factory, exists := r.factories[sensorType]; exists {
+		if pin != "" && pin != LatestVersion {
+			return "", versionedFactory{}, fmt.Errorf("sensor type '%s' is not versioned", sensorType)
+		}
+		return sensorType, versionedFactory{factory: factory}, nil
+	}
+
+	registered := r.versions[sensorType]
+	if len(registered) == 0 {
+		return "", versionedFactory{}, fmt.Errorf("unknown sensor type: %s", key)
+	}
+
+	if pin == "" || pin == LatestVersion {
+		return sensorType, registered[len(registered)-1], nil
+	}
+
+	parsed, count, err := parseVersion(pin)
+	if err != nil {
+		return "", versionedFactory{}, err
+	}
+	for i := len(registered) - 1; i >= 0; i-- {
+		if registered[i].parsed.matches(parsed, count) {
+			return sensorType, registered[i], nil
+		}
+	}
+
+	return "", versionedFactory{}, fmt.Errorf("sensor type '%s' has no version matching '%s'", sensorType, pin)
+}
+
+// Resolve returns the fully pinned key, e.g. "numeric@1.0.0", that a
+// registry key currently refers to. Storing the resolved key keeps later
+// lookups on the same encoding algorithm after newer versions are added.
+func (r *Registry) Resolve(key string) (string, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	sensorType, entry, err := r.resolve(key)
+	if err != nil {
+		return "", err
+	}
+	return SensorKey(sensorType, entry.version), nil
+}
+
+// Version returns the version a registry key resolves to, if the sensor
+// type is versioned
+func (r *Registry) Version(key string) (string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	_, entry, err := r.resolve(key)
+	if err != nil || entry.version == "" {
+		return "", false
+	}
+	return entry.version, true
+}
+
+// Versions returns every registered version of a sensor type, oldest first
+func (r *Registry) Versions(sensorType string) []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	registered := r.versions[sensorType]
+	if len(registered) == 0 {
+		return nil
+	}
+
+	versions := make([]string, len(registered))
+	for i, entry := range registered {
+		versions[i] = entry.version
+	}
+	return versions
+}
+
+// Create creates a new sensor instance for the specified registry key
 func (r *Registry) Create(sensorType string) (SensorInterface, error) {
 	r.mutex.RLock()
-	factory, exists := r.factories[sensorType]
+	_, entry, err := r.resolve(sensorType)
 	r.mutex.RUnlock()
 
-	if !exists {
-		return nil, fmt.Errorf("unknown sensor type: %s", sensorType)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create new sensor instance
-	sensor := factory()
+	sensor := entry.factory()
 	if sensor == nil {
 		return nil, fmt.Errorf("factory for sensor type '%s' returned nil", sensorType)
 	}
 
+	// Versioned factories may be shared between versions, so the sensor
+	// learns its version from the registration rather than the factory
+	if versioned, ok := sensor.(Versioned); ok && entry.version != "" {
+		versioned.SetVersion(entry.version)
+	}
+
 	return sensor, nil
 }
 
-// IsRegistered checks if a sensor type is registered
+// IsRegistered checks if a registry key resolves to a registered sensor
 func (r *Registry) IsRegistered(sensorType string) bool {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
-	_, exists := r.factories[sensorType]
-	return exists
+	_, _, err := r.resolve(sensorType)
+	return err == nil
 }
 
 // List returns a sorted list of all registered sensor types
@@ -75,10 +222,13 @@ func (r *Registry) List() []string {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
-	types := make([]string, 0, len(r.factories))
+	types := make([]string, 0, len(r.factories)+len(r.versions))
 	for sensorType := range r.factories {
 		types = append(types, sensorType)
 	}
+	for sensorType := range r.versions {
+		types = append(types, sensorType)
+	}
 
 	sort.Strings(types)
 	return types
@@ -89,20 +239,49 @@ func (r *Registry) Count() int {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
-	return len(r.factories)
+	return len(r.factories) + len(r.versions)
 }
 
-// Unregister removes a sensor type from the registry
-func (r *Registry) Unregister(sensorType string) error {
+// Unregister removes a sensor type with all its versions from the registry,
+// or a single version when given a fully pinned key such as "numeric@1.0.0"
+func (r *Registry) Unregister(key string) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if _, exists := r.factories[sensorType]; !exists {
-		return fmt.Errorf("sensor type '%s' is not registered", sensorType)
+	sensorType, version := SplitSensorKey(key)
+	if version == "" {
+		_, unversioned := r.factories[sensorType]
+		if !unversioned && len(r.versions[sensorType]) == 0 {
+			return fmt.Errorf("sensor type '%s' is not registered", sensorType)
+		}
+
+		delete(r.factories, sensorType)
+		delete(r.versions, sensorType)
+		return nil
 	}
 
-	delete(r.factories, sensorType)
-	return nil
+	parsed, count, err := parseVersion(version)
+	if err != nil {
+		return err
+	}
+	if count != len(parsed) {
+		return fmt.Errorf("unregistering a single version requires MAJOR.MINOR.PATCH, got '%s'", version)
+	}
+
+	registered := r.versions[sensorType]
+	for i, entry := range registered {
+		if entry.parsed == parsed {
+			registered = append(registered[:i:i], registered[i+1:]...)
+			if len(registered) == 0 {
+				delete(r.versions, sensorType)
+			} else {
+				r.versions[sensorType] = registered
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("version '%s' of sensor type '%s' is not registered", version, sensorType)
 }
 
 // Clear removes all registered sensor types
@@ -111,24 +290,27 @@ func (r *Registry) Clear() {
 	defer r.mutex.Unlock()
 
 	r.factories = make(map[string]SensorFactory)
+	r.versions = make(map[string][]versionedFactory)
 }
 
-// GetFactory returns the factory function for a sensor type (for advanced usage)
+// GetFactory returns the factory function a registry key resolves to (for advanced usage)
 func (r *Registry) GetFactory(sensorType string) (SensorFactory, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
-	factory, exists := r.factories[sensorType]
-	if !exists {
-		return nil, fmt.Errorf("unknown sensor type: %s", sensorType)
+	_, entry, err := r.resolve(sensorType)
+	if err != nil {
+		return nil, err
 	}
 
-	return factory, nil
+	return entry.factory, nil
 }
 
 // RegistryInfo provides information about the registry state
 type RegistryInfo struct {
 	RegisteredTypes []string
+	Versions        map[string]string   // Newest version per versioned sensor type
+	AllVersions     map[string][]string // Every version per versioned sensor type, oldest first
 	Count           int
 	Built_insLoaded bool
 }
@@ -157,8 +339,21 @@ func (r *Registry) GetInfo() RegistryInfo {
 		}
 	}
 
+	r.mutex.RLock()
+	versions := make(map[string]string, len(r.versions))
+	allVersions := make(map[string][]string, len(r.versions))
+	for sensorType, registered := range r.versions {
+		versions[sensorType] = registered[len(registered)-1].version
+		for _, entry := range registered {
+			allVersions[sensorType] = append(allVersions[sensorType], entry.version)
+		}
+	}
+	r.mutex.RUnlock()
+
 	return RegistryInfo{
 		RegisteredTypes: types,
+		Versions:        versions,
+		AllVersions:     allVersions,
 		Count:           len(types),
 		Built_insLoaded: builtInsLoaded,
 	}
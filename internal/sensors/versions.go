@@ -0,0 +1,88 @@
+package sensors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VersionSeparator separates the sensor type from a version pin in registry
+// keys such as "numeric@2" or "numeric@1.0.0"
+const VersionSeparator = "@"
+
+// LatestVersion pins a registry key to the newest registered version, which
+// is also what a bare sensor type resolves to
+const LatestVersion = "latest"
+
+// SplitSensorKey splits a registry key into its sensor type and version pin;
+// the pin is empty for bare sensor types
+func SplitSensorKey(key string) (sensorType, version string) {
+	sensorType, version, _ = strings.Cut(key, VersionSeparator)
+	return sensorType, version
+}
+
+// SensorKey joins a sensor type and version into a registry key
+func SensorKey(sensorType, version string) string {
+	if version == "" {
+		return sensorType
+	}
+	return sensorType + VersionSeparator + version
+}
+
+// Versioned is implemented by sensors that report the version they were
+// registered under; Registry.Create sets it before returning the sensor
+type Versioned interface {
+	// SetVersion sets the version reported in metadata and identity; it
+	// takes effect at the next Configure
+	SetVersion(version string)
+}
+
+// sensorVersion is a parsed MAJOR.MINOR.PATCH version
+type sensorVersion [3]int
+
+// parseVersion parses a version of one to three dot-separated non-negative
+// integers and returns how many components were given
+func parseVersion(version string) (sensorVersion, int, error) {
+	var parsed sensorVersion
+	parts := strings.Split(version, ".")
+	if len(parts) > len(parsed) {
+		return parsed, 0, fmt.Errorf("invalid version '%s': expected MAJOR.MINOR.PATCH", version)
+	}
+
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || strings.Trim(part, "0123456789") != "" {
+			return parsed, 0, fmt.Errorf("invalid version '%s': components must be non-negative integers", version)
+		}
+		parsed[i] = number
+	}
+	return parsed, len(parts), nil
+}
+
+// less orders versions by major, minor and patch
+func (v sensorVersion) less(other sensorVersion) bool {
+	for i := range v {
+		if v[i] != other[i] {
+			return v[i] < other[i]
+		}
+	}
+	return false
+}
+
+// matches reports whether the version starts with the first count
+// components of the pin, so "2" matches 2.x.y and "2.1" matches 2.1.y
+func (v sensorVersion) matches(pin sensorVersion, count int) bool {
+	for i := 0; i < count; i++ {
+		if v[i] != pin[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// versionedFactory is one registered version of a sensor type
+type versionedFactory struct {
+	version string
+	parsed  sensorVersion
+	factory SensorFactory
+}
@@ -1,154 +1,136 @@
 package contract
 
 import (
+	"fmt"
+	"math/rand"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/htm-project/neural-api/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestCategoricalEncoderPerformance validates sub-millisecond encoding requirement
 func TestCategoricalEncoderPerformance(t *testing.T) {
 	t.Run("Sub-millisecond encoding constraint", func(t *testing.T) {
-		// This will fail until CategoricalEncoder is implemented
-		t.Skip("CategoricalEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// encoder := createCategoricalEncoder()
-		// config := SensorConfig{
-		//     SDRWidth: 2048,
-		//     TargetSparsity: 0.02,
-		// }
-		// encoder.Configure(config)
-		//
-		// benchmark := NewSubMillisecondBenchmark()
-		//
-		// testCategories := []string{"category_a", "category_b", "category_c", "category_d"}
-		// for _, category := range testCategories {
-		//     operation := func() {
-		//         _, err := encoder.Encode(category)
-		//         require.NoError(t, err)
-		//     }
-		//     benchmark.Run(t, fmt.Sprintf("CategoricalEncode_%s", category), operation)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 2048
+		config.TargetSparsity = 0.02
+		encoder := createCategoricalEncoder(t, config)
+
+		benchmark := tests.NewSubMillisecondBenchmark()
+
+		testCategories := []string{"category_a", "category_b", "category_c", "category_d"}
+		for _, category := range testCategories {
+			operation := func() {
+				_, err := encoder.Encode(category)
+				require.NoError(t, err)
+			}
+			benchmark.Run(t, fmt.Sprintf("CategoricalEncode_%s", category), operation)
+		}
 	})
 
 	t.Run("Performance with hash collisions", func(t *testing.T) {
-		t.Skip("CategoricalEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
 		// Test performance when hash collision handling is triggered
-		// encoder := createCategoricalEncoder()
-		// config := SensorConfig{SDRWidth: 64, TargetSparsity: 0.5} // High sparsity to force collisions
-		// encoder.Configure(config)
-		//
-		// // Generate categories likely to cause hash collisions
-		// collisionCategories := generateCollisionProneCategoriesCategories()
-		//
-		// for _, category := range collisionCategories {
-		//     start := time.Now()
-		//     _, err := encoder.Encode(category)
-		//     elapsed := time.Since(start)
-		//
-		//     require.NoError(t, err)
-		//     assert.Less(t, elapsed, time.Millisecond,
-		//                 "Hash collision handling exceeded 1ms for category: %s", category)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 100
+		config.TargetSparsity = 0.1 // Densest allowed SDR to force collisions
+		encoder := createCategoricalEncoder(t, config)
+
+		// Many categories on a narrow SDR make bit collisions certain
+		for i := 0; i < 200; i++ {
+			category := fmt.Sprintf("collision_%d", i)
+			elapsed := timeEncode(t, encoder, category)
+			assert.Less(t, elapsed, time.Millisecond,
+				"Hash collision handling exceeded 1ms for category: %s", category)
+		}
 	})
 
 	t.Run("Large vocabulary performance", func(t *testing.T) {
-		t.Skip("CategoricalEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
 		// Test performance with large number of unique categories
-		// encoder := createCategoricalEncoder()
-		// config := SensorConfig{SDRWidth: 4096, TargetSparsity: 0.01}
-		// encoder.Configure(config)
-		//
-		// // Create large vocabulary
-		// largeVocabulary := make([]string, 10000)
-		// for i := 0; i < 10000; i++ {
-		//     largeVocabulary[i] = fmt.Sprintf("category_%d", i)
-		// }
-		//
-		// // Test random sampling from large vocabulary
-		// for i := 0; i < 100; i++ {
-		//     category := largeVocabulary[rand.Intn(len(largeVocabulary))]
-		//     start := time.Now()
-		//     _, err := encoder.Encode(category)
-		//     elapsed := time.Since(start)
-		//
-		//     require.NoError(t, err)
-		//     assert.Less(t, elapsed, time.Millisecond,
-		//                 "Large vocabulary encoding exceeded 1ms for: %s", category)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 4096
+		config.TargetSparsity = 0.01
+		encoder := createCategoricalEncoder(t, config)
+
+		// Create large vocabulary
+		largeVocabulary := make([]string, 10000)
+		for i := 0; i < 10000; i++ {
+			largeVocabulary[i] = fmt.Sprintf("category_%d", i)
+		}
+
+		// Test random sampling from large vocabulary
+		rng := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			category := largeVocabulary[rng.Intn(len(largeVocabulary))]
+			elapsed := timeEncode(t, encoder, category)
+			assert.Less(t, elapsed, time.Millisecond,
+				"Large vocabulary encoding exceeded 1ms for: %s", category)
+		}
 	})
 
 	t.Run("String length performance scaling", func(t *testing.T) {
-		t.Skip("CategoricalEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
 		// Test that encoding time doesn't scale linearly with string length
-		// encoder := createCategoricalEncoder()
-		// config := SensorConfig{SDRWidth: 2048, TargetSparsity: 0.02}
-		// encoder.Configure(config)
-		//
-		// stringLengths := []int{10, 50, 100, 500, 1000}
-		// for _, length := range stringLengths {
-		//     category := strings.Repeat("a", length)
-		//     start := time.Now()
-		//     _, err := encoder.Encode(category)
-		//     elapsed := time.Since(start)
-		//
-		//     require.NoError(t, err)
-		//     assert.Less(t, elapsed, time.Millisecond,
-		//                 "String length %d encoding exceeded 1ms", length)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 2048
+		config.TargetSparsity = 0.02
+		encoder := createCategoricalEncoder(t, config)
+
+		stringLengths := []int{10, 50, 100, 500, 1000}
+		for _, length := range stringLengths {
+			category := strings.Repeat("a", length)
+			elapsed := timeEncode(t, encoder, category)
+			assert.Less(t, elapsed, time.Millisecond,
+				"String length %d encoding exceeded 1ms", length)
+		}
 	})
 
 	t.Run("Memory allocation efficiency", func(t *testing.T) {
-		t.Skip("CategoricalEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// encoder := createCategoricalEncoder()
-		// config := SensorConfig{SDRWidth: 2048, TargetSparsity: 0.02}
-		// encoder.Configure(config)
-		//
-		// benchmark := NewSubMillisecondBenchmark()
-		// operation := func() {
-		//     _, err := encoder.Encode("test_category")
-		//     require.NoError(t, err)
-		// }
-		//
-		// benchmark.BenchmarkMemory(t, "CategoricalEncode_Memory", operation)
-		// // Should minimize allocations for high-frequency encoding
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 2048
+		config.TargetSparsity = 0.02
+		encoder := createCategoricalEncoder(t, config)
+
+		benchmark := tests.NewSubMillisecondBenchmark()
+		operation := func() {
+			_, err := encoder.Encode("test_category")
+			require.NoError(t, err)
+		}
+
+		benchmark.BenchmarkMemory(t, "CategoricalEncode_Memory", operation)
 	})
 
 	t.Run("Unicode category performance", func(t *testing.T) {
-		t.Skip("CategoricalEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
 		// Test performance with Unicode category names
-		// encoder := createCategoricalEncoder()
-		// config := SensorConfig{SDRWidth: 2048, TargetSparsity: 0.02}
-		// encoder.Configure(config)
-		//
-		// unicodeCategories := []string{
-		//     "категория", // Russian
-		//     "カテゴリー", // Japanese
-		//     "类别", // Chinese
-		//     "🏷️📊📈", // Emojis
-		// }
-		//
-		// for _, category := range unicodeCategories {
-		//     start := time.Now()
-		//     _, err := encoder.Encode(category)
-		//     elapsed := time.Since(start)
-		//
-		//     require.NoError(t, err)
-		//     assert.Less(t, elapsed, time.Millisecond,
-		//                 "Unicode category encoding exceeded 1ms: %s", category)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 2048
+		config.TargetSparsity = 0.02
+		encoder := createCategoricalEncoder(t, config)
+
+		unicodeCategories := []string{
+			"категория", // Russian
+			"カテゴリー",     // Japanese
+			"类别",        // Chinese
+			"🏷️📊📈",      // Emojis
+		}
+
+		for _, category := range unicodeCategories {
+			elapsed := timeEncode(t, encoder, category)
+			assert.Less(t, elapsed, time.Millisecond,
+				"Unicode category encoding exceeded 1ms: %s", category)
+		}
 	})
 }
 
-// Helper functions will be implemented alongside CategoricalEncoder
-// func createCategoricalEncoder() SensorInterface { ... }
-// func generateCollisionProneCategoriesCategories() []string { ... }
+// createCategoricalEncoder creates and configures an open vocabulary categorical encoder
+func createCategoricalEncoder(t *testing.T, config *sensors.SensorConfig) sensors.SensorInterface {
+	t.Helper()
+
+	encoder := encoders.NewCategoricalSensor()
+	require.NoError(t, encoder.Configure(*config), "Categorical encoder configuration should succeed")
+	return encoder
+}
@@ -0,0 +1,27 @@
+package contract
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/stretchr/testify/require"
+)
+
+// timeEncode returns the fastest of a few encodings of the input, so that a
+// single scheduler hiccup does not fail the latency check
+func timeEncode(t *testing.T, encoder sensors.SensorInterface, input interface{}) time.Duration {
+	t.Helper()
+
+	fastest := time.Duration(math.MaxInt64)
+	for i := 0; i < 5; i++ {
+		start := time.Now()
+		_, err := encoder.Encode(input)
+		elapsed := time.Since(start)
+
+		require.NoError(t, err)
+		fastest = min(fastest, elapsed)
+	}
+	return fastest
+}
@@ -1,139 +1,130 @@
 package contract
 
 import (
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/htm-project/neural-api/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestNumericEncoderPerformance validates sub-millisecond encoding requirement
 func TestNumericEncoderPerformance(t *testing.T) {
 	t.Run("Sub-millisecond encoding constraint", func(t *testing.T) {
-		// This will fail until NumericEncoder is implemented
-		t.Skip("NumericEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// encoder := createNumericEncoder()
-		// config := SensorConfig{
-		//     SDRWidth: 2048,
-		//     TargetSparsity: 0.02,
-		//     Resolution: 0.1,
-		//     Range: Range{Min: 0.0, Max: 100.0},
-		// }
-		// encoder.Configure(config)
-		//
-		// benchmark := NewSubMillisecondBenchmark()
-		//
-		// testValues := []float64{0.0, 25.5, 50.0, 75.3, 100.0}
-		// for _, value := range testValues {
-		//     operation := func() {
-		//         _, err := encoder.Encode(value)
-		//         require.NoError(t, err)
-		//     }
-		//     benchmark.Run(t, fmt.Sprintf("NumericEncode_%.1f", value), operation)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 2048
+		config.TargetSparsity = 0.02
+		config.Resolution = 0.1
+		config.Range = &sensors.Range{Min: 0.0, Max: 100.0}
+		encoder := createNumericEncoder(t, config)
+
+		benchmark := tests.NewSubMillisecondBenchmark()
+
+		testValues := []float64{0.0, 25.5, 50.0, 75.3, 100.0}
+		for _, value := range testValues {
+			operation := func() {
+				_, err := encoder.Encode(value)
+				require.NoError(t, err)
+			}
+			benchmark.Run(t, fmt.Sprintf("NumericEncode_%.1f", value), operation)
+		}
 	})
 
 	t.Run("Performance scaling with precision", func(t *testing.T) {
-		t.Skip("NumericEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test that encoding time remains sub-millisecond across different resolutions
-		// encoder := createNumericEncoder()
-		//
-		// resolutions := []float64{1.0, 0.1, 0.01, 0.001}
-		// for _, resolution := range resolutions {
-		//     config := SensorConfig{
-		//         SDRWidth: 2048,
-		//         TargetSparsity: 0.02,
-		//         Resolution: resolution,
-		//         Range: Range{Min: 0.0, Max: 100.0},
-		//     }
-		//     encoder.Configure(config)
-		//
-		//     start := time.Now()
-		//     _, err := encoder.Encode(42.5)
-		//     elapsed := time.Since(start)
-		//
-		//     require.NoError(t, err)
-		//     assert.Less(t, elapsed, time.Millisecond,
-		//                 "Resolution %.3f encoding exceeded 1ms limit", resolution)
-		// }
+		// Test that encoding time remains sub-millisecond across different resolutions;
+		// finer resolutions need a range that fits the SDR's bucket positions
+		testCases := []struct {
+			resolution float64
+			max        float64
+		}{
+			{1.0, 1000.0},
+			{0.1, 100.0},
+			{0.01, 10.0},
+			{0.001, 1.0},
+		}
+
+		for _, tc := range testCases {
+			config := sensors.NewSensorConfig()
+			config.SDRWidth = 2048
+			config.TargetSparsity = 0.02
+			config.Resolution = tc.resolution
+			config.Range = &sensors.Range{Min: 0.0, Max: tc.max}
+			encoder := createNumericEncoder(t, config)
+
+			elapsed := timeEncode(t, encoder, tc.max*0.425)
+			assert.Less(t, elapsed, time.Millisecond,
+				"Resolution %.3f encoding exceeded 1ms limit", tc.resolution)
+		}
 	})
 
 	t.Run("Memory allocation efficiency", func(t *testing.T) {
-		t.Skip("NumericEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// encoder := createNumericEncoder()
-		// config := SensorConfig{SDRWidth: 2048, TargetSparsity: 0.02}
-		// encoder.Configure(config)
-		//
-		// benchmark := NewSubMillisecondBenchmark()
-		// operation := func() {
-		//     _, err := encoder.Encode(42.5)
-		//     require.NoError(t, err)
-		// }
-		//
-		// benchmark.BenchmarkMemory(t, "NumericEncode_Memory", operation)
-		// // Should minimize allocations for high-frequency encoding
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 2048
+		config.TargetSparsity = 0.02
+		encoder := createNumericEncoder(t, config)
+
+		benchmark := tests.NewSubMillisecondBenchmark()
+		operation := func() {
+			_, err := encoder.Encode(42.5)
+			require.NoError(t, err)
+		}
+
+		benchmark.BenchmarkMemory(t, "NumericEncode_Memory", operation)
 	})
 
 	t.Run("Range boundary performance", func(t *testing.T) {
-		t.Skip("NumericEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
 		// Test performance at range boundaries and edge cases
-		// encoder := createNumericEncoder()
-		// config := SensorConfig{
-		//     SDRWidth: 2048,
-		//     TargetSparsity: 0.02,
-		//     Range: Range{Min: -1000.0, Max: 1000.0},
-		// }
-		// encoder.Configure(config)
-		//
-		// edgeCases := []float64{-1000.0, -0.001, 0.0, 0.001, 1000.0}
-		// for _, value := range edgeCases {
-		//     start := time.Now()
-		//     _, err := encoder.Encode(value)
-		//     elapsed := time.Since(start)
-		//
-		//     require.NoError(t, err)
-		//     assert.Less(t, elapsed, time.Millisecond,
-		//                 "Edge case %.3f encoding exceeded 1ms limit", value)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 2048
+		config.TargetSparsity = 0.02
+		config.Resolution = 1.0
+		config.Range = &sensors.Range{Min: -1000.0, Max: 1000.0}
+		encoder := createNumericEncoder(t, config)
+
+		edgeCases := []float64{-1000.0, -0.001, 0.0, 0.001, 1000.0}
+		for _, value := range edgeCases {
+			elapsed := timeEncode(t, encoder, value)
+			assert.Less(t, elapsed, time.Millisecond,
+				"Edge case %.3f encoding exceeded 1ms limit", value)
+		}
 	})
 
 	t.Run("Consistency with performance", func(t *testing.T) {
-		t.Skip("NumericEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
 		// Verify that sub-millisecond performance doesn't compromise consistency
-		// encoder := createNumericEncoder()
-		// config := SensorConfig{SDRWidth: 1000, TargetSparsity: 0.02}
-		// encoder.Configure(config)
-		//
-		// testValue := 42.5
-		// var firstSDR SDR
-		//
-		// // First encoding
-		// start := time.Now()
-		// firstSDR, err := encoder.Encode(testValue)
-		// firstElapsed := time.Since(start)
-		// require.NoError(t, err)
-		// assert.Less(t, firstElapsed, time.Millisecond)
-		//
-		// // Subsequent encodings should be identical and fast
-		// for i := 0; i < 100; i++ {
-		//     start = time.Now()
-		//     sdr, err := encoder.Encode(testValue)
-		//     elapsed := time.Since(start)
-		//
-		//     require.NoError(t, err)
-		//     assert.Less(t, elapsed, time.Millisecond)
-		//     assert.Equal(t, firstSDR.ActiveBits(), sdr.ActiveBits(),
-		//                  "Encoding consistency compromised at iteration %d", i)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 2048
+		config.TargetSparsity = 0.02
+		encoder := createNumericEncoder(t, config)
+
+		testValue := 42.5
+
+		// First encoding
+		firstSDR, err := encoder.Encode(testValue)
+		require.NoError(t, err)
+
+		// Subsequent encodings should be identical and fast
+		for i := 0; i < 100; i++ {
+			start := time.Now()
+			sdr, err := encoder.Encode(testValue)
+			elapsed := time.Since(start)
+
+			require.NoError(t, err)
+			assert.Less(t, elapsed, time.Millisecond)
+			assert.Equal(t, firstSDR.ActiveBits(), sdr.ActiveBits(),
+				"Encoding consistency compromised at iteration %d", i)
+		}
 	})
 }
 
-// Helper functions will be implemented alongside NumericEncoder
-// func createNumericEncoder() SensorInterface { ... }
+// createNumericEncoder creates and configures a numeric encoder
+func createNumericEncoder(t *testing.T, config *sensors.SensorConfig) sensors.SensorInterface {
+	t.Helper()
+
+	encoder := encoders.NewNumericSensor()
+	require.NoError(t, encoder.Configure(*config), "Numeric encoder configuration should succeed")
+	return encoder
+}
@@ -1,86 +1,81 @@
 package contract
 
 import (
+	"sort"
 	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/htm-project/neural-api/internal/sensors/sdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestSDRInterface validates the SDR interface contract
 func TestSDRInterface(t *testing.T) {
 	t.Run("Width returns positive value", func(t *testing.T) {
-		// This will fail until SDR is implemented
-		t.Skip("SDR interface not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// sdr := createTestSDR(2048, []int{10, 50, 100})
-		// assert.Equal(t, 2048, sdr.Width())
+		sdr := createTestSDR(t, 2048, []int{10, 50, 100})
+		assert.Equal(t, 2048, sdr.Width())
 	})
 
 	t.Run("ActiveBits returns sorted indices", func(t *testing.T) {
-		t.Skip("SDR interface not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// sdr := createTestSDR(1000, []int{100, 50, 200})
-		// activeBits := sdr.ActiveBits()
-		// assert.Equal(t, []int{50, 100, 200}, activeBits)
-		// assert.True(t, sort.IntsAreSorted(activeBits))
+		sdr := createTestSDR(t, 1000, []int{100, 50, 200})
+		activeBits := sdr.ActiveBits()
+		assert.Equal(t, []int{50, 100, 200}, activeBits)
+		assert.True(t, sort.IntsAreSorted(activeBits))
 	})
 
 	t.Run("Sparsity calculation is correct", func(t *testing.T) {
-		t.Skip("SDR interface not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// sdr := createTestSDR(1000, []int{10, 20, 30}) // 3 active bits
-		// expected := 3.0 / 1000.0 // 0.003 = 0.3%
-		// assert.InDelta(t, expected, sdr.Sparsity(), 0.0001)
+		sdr := createTestSDR(t, 1000, []int{10, 20, 30}) // 3 active bits
+		expected := 3.0 / 1000.0                         // 0.003 = 0.3%
+		assert.InDelta(t, expected, sdr.Sparsity(), 0.0001)
 	})
 
 	t.Run("IsActive returns correct state", func(t *testing.T) {
-		t.Skip("SDR interface not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// sdr := createTestSDR(100, []int{10, 50, 90})
-		// assert.True(t, sdr.IsActive(10))
-		// assert.True(t, sdr.IsActive(50))
-		// assert.True(t, sdr.IsActive(90))
-		// assert.False(t, sdr.IsActive(0))
-		// assert.False(t, sdr.IsActive(25))
-		// assert.False(t, sdr.IsActive(99))
+		sdr := createTestSDR(t, 100, []int{10, 50, 90})
+		assert.True(t, sdr.IsActive(10))
+		assert.True(t, sdr.IsActive(50))
+		assert.True(t, sdr.IsActive(90))
+		assert.False(t, sdr.IsActive(0))
+		assert.False(t, sdr.IsActive(25))
+		assert.False(t, sdr.IsActive(99))
 	})
 
 	t.Run("Overlap calculation", func(t *testing.T) {
-		t.Skip("SDR interface not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// sdr1 := createTestSDR(100, []int{10, 20, 30, 40})
-		// sdr2 := createTestSDR(100, []int{20, 30, 50, 60})
-		// overlap := sdr1.Overlap(sdr2)
-		// assert.Equal(t, 2, overlap) // bits 20 and 30 overlap
+		sdr1 := createTestSDR(t, 100, []int{10, 20, 30, 40})
+		sdr2 := createTestSDR(t, 100, []int{20, 30, 50, 60})
+		overlap := sdr1.Overlap(sdr2)
+		assert.Equal(t, 2, overlap) // bits 20 and 30 overlap
 	})
 
 	t.Run("Similarity normalized overlap", func(t *testing.T) {
-		t.Skip("SDR interface not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// sdr1 := createTestSDR(100, []int{10, 20, 30, 40}) // 4 active
-		// sdr2 := createTestSDR(100, []int{20, 30, 50, 60}) // 4 active
-		// similarity := sdr1.Similarity(sdr2)
-		// expected := 2.0 / 4.0 // 2 overlapping / 4 active = 0.5
-		// assert.InDelta(t, expected, similarity, 0.0001)
+		sdr1 := createTestSDR(t, 100, []int{10, 20, 30, 40}) // 4 active
+		sdr2 := createTestSDR(t, 100, []int{20, 30, 50, 60}) // 4 active
+		similarity := sdr1.Similarity(sdr2)
+		expected := 2.0 / 4.0 // 2 overlapping / 4 active = 0.5
+		assert.InDelta(t, expected, similarity, 0.0001)
 	})
 
 	t.Run("HTM sparsity constraints", func(t *testing.T) {
-		t.Skip("SDR interface not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test that SDR maintains 2-5% sparsity for HTM compliance
-		// sdr := createTestSDR(2000, []int{...}) // create with ~40-100 active bits
-		// sparsity := sdr.Sparsity()
-		// assert.GreaterOrEqual(t, sparsity, 0.02, "Sparsity below HTM minimum")
-		// assert.LessOrEqual(t, sparsity, 0.05, "Sparsity above HTM maximum")
+		// Test that encoded SDRs maintain 2-5% sparsity for HTM compliance
+		encoder := encoders.NewNumericSensor()
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 2000
+		require.NoError(t, encoder.Configure(*config))
+
+		sdr, err := encoder.Encode(42.0)
+		require.NoError(t, err)
+		sparsity := sdr.Sparsity()
+		assert.GreaterOrEqual(t, sparsity, 0.02, "Sparsity below HTM minimum")
+		assert.LessOrEqual(t, sparsity, 0.05, "Sparsity above HTM maximum")
 	})
 }
 
-// createTestSDR would be implemented alongside SDR implementation
-// func createTestSDR(width int, activeBits []int) SDR {
-//     // Implementation will be added with core SDR implementation
-// }
+// createTestSDR creates a public SDR with the given width and active bits
+func createTestSDR(t *testing.T, width int, activeBits []int) sensors.SDR {
+	t.Helper()
+
+	internal, err := sdr.NewSDR(width, activeBits)
+	require.NoError(t, err)
+	return sensors.NewSDRWrapper(internal)
+}
@@ -1,152 +1,127 @@
 package contract
 
 import (
+	"sync"
 	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
 )
 
 // TestSensorRegistry validates the SensorRegistry contract
 func TestSensorRegistry(t *testing.T) {
 	t.Run("Register sensor factory", func(t *testing.T) {
-		// This will fail until SensorRegistry is implemented
-		t.Skip("SensorRegistry not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := NewSensorRegistry()
-		//
-		// // Register a sensor factory
-		// factory := func() SensorInterface { return &TestSensor{} }
-		// err := registry.Register("test", factory)
-		// assert.NoError(t, err, "Valid registration should succeed")
-		//
-		// // Test duplicate registration
-		// err = registry.Register("test", factory)
-		// assert.Error(t, err, "Duplicate registration should fail")
+		registry := sensors.NewRegistry()
+
+		// Register a sensor factory
+		err := registry.Register("test", newTestSensor)
+		assert.NoError(t, err, "Valid registration should succeed")
+
+		// Test duplicate registration
+		err = registry.Register("test", newTestSensor)
+		assert.Error(t, err, "Duplicate registration should fail")
 	})
 
 	t.Run("Create sensor by type", func(t *testing.T) {
-		t.Skip("SensorRegistry not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := NewSensorRegistry()
-		// factory := func() SensorInterface { return &TestSensor{} }
-		// registry.Register("test", factory)
-		//
-		// sensor, err := registry.Create("test")
-		// assert.NoError(t, err, "Creating registered sensor should succeed")
-		// assert.NotNil(t, sensor, "Created sensor should not be nil")
-		//
-		// // Test unknown sensor type
-		// sensor, err = registry.Create("unknown")
-		// assert.Error(t, err, "Creating unknown sensor should fail")
-		// assert.Nil(t, sensor, "Unknown sensor creation should return nil")
+		registry := sensors.NewRegistry()
+		registry.Register("test", newTestSensor)
+
+		sensor, err := registry.Create("test")
+		assert.NoError(t, err, "Creating registered sensor should succeed")
+		assert.NotNil(t, sensor, "Created sensor should not be nil")
+
+		// Test unknown sensor type
+		sensor, err = registry.Create("unknown")
+		assert.Error(t, err, "Creating unknown sensor should fail")
+		assert.Nil(t, sensor, "Unknown sensor creation should return nil")
 	})
 
 	t.Run("List registered sensor types", func(t *testing.T) {
-		t.Skip("SensorRegistry not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := NewSensorRegistry()
-		//
-		// // Empty registry
-		// types := registry.List()
-		// assert.Empty(t, types, "Empty registry should return empty list")
-		//
-		// // After registrations
-		// factory := func() SensorInterface { return &TestSensor{} }
-		// registry.Register("numeric", factory)
-		// registry.Register("categorical", factory)
-		//
-		// types = registry.List()
-		// assert.Len(t, types, 2, "Registry should list all registered types")
-		// assert.Contains(t, types, "numeric", "List should contain numeric")
-		// assert.Contains(t, types, "categorical", "List should contain categorical")
+		registry := sensors.NewRegistry()
+
+		// Empty registry
+		types := registry.List()
+		assert.Empty(t, types, "Empty registry should return empty list")
+
+		// After registrations
+		registry.Register("numeric", newTestSensor)
+		registry.Register("categorical", newTestSensor)
+
+		types = registry.List()
+		assert.Len(t, types, 2, "Registry should list all registered types")
+		assert.Contains(t, types, "numeric", "List should contain numeric")
+		assert.Contains(t, types, "categorical", "List should contain categorical")
 	})
 
 	t.Run("IsRegistered check", func(t *testing.T) {
-		t.Skip("SensorRegistry not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := NewSensorRegistry()
-		// factory := func() SensorInterface { return &TestSensor{} }
-		//
-		// assert.False(t, registry.IsRegistered("test"), "Unregistered type should return false")
-		//
-		// registry.Register("test", factory)
-		// assert.True(t, registry.IsRegistered("test"), "Registered type should return true")
+		registry := sensors.NewRegistry()
+
+		assert.False(t, registry.IsRegistered("test"), "Unregistered type should return false")
+
+		registry.Register("test", newTestSensor)
+		assert.True(t, registry.IsRegistered("test"), "Registered type should return true")
 	})
 
 	t.Run("Built-in sensor types registration", func(t *testing.T) {
-		t.Skip("SensorRegistry not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := NewSensorRegistry()
-		//
-		// // Test registering all built-in sensor types
-		// err := registry.RegisterBuiltIns()
-		// assert.NoError(t, err, "Built-in registration should succeed")
-		//
-		// expectedTypes := []string{"numeric", "categorical", "text", "spatial"}
-		// for _, sensorType := range expectedTypes {
-		//     assert.True(t, registry.IsRegistered(sensorType),
-		//                 "Built-in type %s should be registered", sensorType)
-		// }
+		registry := sensors.NewRegistry()
+
+		// Test registering all built-in sensor types
+		err := encoders.RegisterBuiltins(registry)
+		assert.NoError(t, err, "Built-in registration should succeed")
+
+		expectedTypes := []string{"numeric", "categorical", "text", "spatial"}
+		for _, sensorType := range expectedTypes {
+			assert.True(t, registry.IsRegistered(sensorType),
+				"Built-in type %s should be registered", sensorType)
+		}
+		assert.True(t, registry.GetInfo().Built_insLoaded)
 	})
 
 	t.Run("Factory function validation", func(t *testing.T) {
-		t.Skip("SensorRegistry not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := NewSensorRegistry()
-		//
-		// // Test nil factory rejection
-		// err := registry.Register("test", nil)
-		// assert.Error(t, err, "Nil factory should be rejected")
-		//
-		// // Test empty type name rejection
-		// factory := func() SensorInterface { return &TestSensor{} }
-		// err = registry.Register("", factory)
-		// assert.Error(t, err, "Empty type name should be rejected")
+		registry := sensors.NewRegistry()
+
+		// Test nil factory rejection
+		err := registry.Register("test", nil)
+		assert.Error(t, err, "Nil factory should be rejected")
+
+		// Test empty type name rejection
+		err = registry.Register("", newTestSensor)
+		assert.Error(t, err, "Empty type name should be rejected")
 	})
 
 	t.Run("Thread safety", func(t *testing.T) {
-		t.Skip("SensorRegistry not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := NewSensorRegistry()
-		// factory := func() SensorInterface { return &TestSensor{} }
-		//
-		// // Note: HTM sensor package is single-threaded, but registry should be safe
-		// // for read operations from multiple goroutines during setup
-		// var wg sync.WaitGroup
-		//
-		// // Multiple concurrent reads should be safe
-		// for i := 0; i < 10; i++ {
-		//     wg.Add(1)
-		//     go func() {
-		//         defer wg.Done()
-		//         _ = registry.List()
-		//         _ = registry.IsRegistered("test")
-		//     }()
-		// }
-		// wg.Wait()
+		registry := sensors.NewRegistry()
+		registry.Register("test", newTestSensor)
+
+		// Note: HTM sensor package is single-threaded, but registry should be safe
+		// for read operations from multiple goroutines during setup
+		var wg sync.WaitGroup
+
+		// Multiple concurrent reads should be safe
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = registry.List()
+				_ = registry.IsRegistered("test")
+			}()
+		}
+		wg.Wait()
 	})
 
 	t.Run("Sensor creation independence", func(t *testing.T) {
-		t.Skip("SensorRegistry not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := NewSensorRegistry()
-		// factory := func() SensorInterface { return &TestSensor{} }
-		// registry.Register("test", factory)
-		//
-		// sensor1, _ := registry.Create("test")
-		// sensor2, _ := registry.Create("test")
-		//
-		// assert.NotSame(t, sensor1, sensor2, "Each Create call should return new instance")
+		registry := sensors.NewRegistry()
+		registry.Register("test", newTestSensor)
+
+		sensor1, _ := registry.Create("test")
+		sensor2, _ := registry.Create("test")
+
+		assert.NotSame(t, sensor1, sensor2, "Each Create call should return new instance")
 	})
 }
 
-// TestSensor would be implemented alongside interfaces for testing
-// type TestSensor struct { ... }
-// func (ts *TestSensor) Encode(input interface{}) (SDR, error) { ... }
-// etc.
+// newTestSensor creates the sensor registered by the registry contract tests
+func newTestSensor() sensors.SensorInterface {
+	return encoders.NewPassthroughSensor()
+}
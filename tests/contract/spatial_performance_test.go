@@ -1,239 +1,209 @@
 package contract
 
 import (
+	"fmt"
+	"math"
 	"testing"
+	"time"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/htm-project/neural-api/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestSpatialEncoderPerformance validates sub-millisecond encoding requirement
 func TestSpatialEncoderPerformance(t *testing.T) {
 	t.Run("Sub-millisecond encoding constraint", func(t *testing.T) {
-		// This will fail until SpatialEncoder is implemented
-		t.Skip("SpatialEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// encoder := createSpatialEncoder()
-		// config := SensorConfig{
-		//     SDRWidth: 4096,
-		//     TargetSparsity: 0.02,
-		//     CustomParams: map[string]interface{}{
-		//         "topology_width": 64,
-		//         "topology_height": 64,
-		//     },
-		// }
-		// encoder.Configure(config)
-		//
-		// benchmark := NewSubMillisecondBenchmark()
-		//
-		// testCoordinates := [][]float64{
-		//     {0.0, 0.0}, {0.5, 0.5}, {1.0, 1.0},
-		//     {0.25, 0.75}, {0.75, 0.25},
-		// }
-		//
-		// for i, coord := range testCoordinates {
-		//     operation := func() {
-		//         _, err := encoder.Encode(coord)
-		//         require.NoError(t, err)
-		//     }
-		//     benchmark.Run(t, fmt.Sprintf("SpatialEncode_Coord%d", i), operation)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 4096
+		config.TargetSparsity = 0.02
+		config.SetParam("grid_width", 64)
+		config.SetParam("grid_height", 64)
+		encoder := createSpatialEncoder(t, config)
+
+		benchmark := tests.NewSubMillisecondBenchmark()
+
+		testGrids := [][][]float64{
+			blobGrid(64, 0.0, 0.0),
+			blobGrid(64, 0.5, 0.5),
+			blobGrid(64, 1.0, 1.0),
+			blobGrid(64, 0.25, 0.75),
+			blobGrid(64, 0.75, 0.25),
+		}
+
+		for i, grid := range testGrids {
+			operation := func() {
+				_, err := encoder.Encode(grid)
+				require.NoError(t, err)
+			}
+			benchmark.Run(t, fmt.Sprintf("SpatialEncode_Grid%d", i), operation)
+		}
 	})
 
 	t.Run("High-resolution spatial performance", func(t *testing.T) {
-		t.Skip("SpatialEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test performance with high-resolution spatial grids
-		// encoder := createSpatialEncoder()
-		//
-		// resolutions := []int{32, 64, 128, 256}
-		// for _, resolution := range resolutions {
-		//     config := SensorConfig{
-		//         SDRWidth: resolution * resolution / 10, // Adjust SDR size
-		//         TargetSparsity: 0.02,
-		//         CustomParams: map[string]interface{}{
-		//             "topology_width": resolution,
-		//             "topology_height": resolution,
-		//         },
-		//     }
-		//     encoder.Configure(config)
-		//
-		//     coordinate := []float64{0.333, 0.666}
-		//     start := time.Now()
-		//     _, err := encoder.Encode(coordinate)
-		//     elapsed := time.Since(start)
-		//
-		//     require.NoError(t, err)
-		//     assert.Less(t, elapsed, time.Millisecond,
-		//                 "Resolution %dx%d exceeded 1ms encoding limit", resolution, resolution)
-		// }
+		// Inputs larger than the cell grid are resampled onto it
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 4096
+		config.TargetSparsity = 0.02
+		encoder := createSpatialEncoder(t, config)
+
+		resolutions := []int{32, 64, 128, 256}
+		for _, resolution := range resolutions {
+			grid := blobGrid(resolution, 0.333, 0.666)
+
+			elapsed := timeEncode(t, encoder, grid)
+			assert.Less(t, elapsed, time.Millisecond,
+				"Resolution %dx%d exceeded 1ms encoding limit", resolution, resolution)
+		}
 	})
 
-	t.Run("Multi-dimensional spatial performance", func(t *testing.T) {
-		t.Skip("SpatialEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test performance with 2D, 3D, and higher-dimensional coordinates
-		// encoder := createSpatialEncoder()
-		//
-		// testCases := []struct {
-		//     dimensions int
-		//     coordinate []float64
-		// }{
-		//     {2, []float64{0.5, 0.5}},
-		//     {3, []float64{0.3, 0.6, 0.9}},
-		//     {4, []float64{0.25, 0.5, 0.75, 1.0}},
-		//     {5, []float64{0.2, 0.4, 0.6, 0.8, 1.0}},
-		// }
-		//
-		// for _, tc := range testCases {
-		//     config := SensorConfig{
-		//         SDRWidth: 4096,
-		//         TargetSparsity: 0.02,
-		//         CustomParams: map[string]interface{}{
-		//             "dimensions": tc.dimensions,
-		//         },
-		//     }
-		//     encoder.Configure(config)
-		//
-		//     start := time.Now()
-		//     _, err := encoder.Encode(tc.coordinate)
-		//     elapsed := time.Since(start)
-		//
-		//     require.NoError(t, err)
-		//     assert.Less(t, elapsed, time.Millisecond,
-		//                 "%dD coordinate encoding exceeded 1ms limit", tc.dimensions)
-		// }
+	t.Run("Non-square grid performance", func(t *testing.T) {
+		testCases := []struct {
+			gridWidth  int
+			gridHeight int
+		}{
+			{128, 32},
+			{32, 128},
+			{100, 40},
+		}
+
+		for _, tc := range testCases {
+			config := sensors.NewSensorConfig()
+			config.SDRWidth = 4096
+			config.TargetSparsity = 0.02
+			config.SetParam("grid_width", tc.gridWidth)
+			config.SetParam("grid_height", tc.gridHeight)
+			encoder := createSpatialEncoder(t, config)
+
+			elapsed := timeEncode(t, encoder, blobGrid(128, 0.5, 0.5))
+			assert.Less(t, elapsed, time.Millisecond,
+				"%dx%d grid encoding exceeded 1ms limit", tc.gridWidth, tc.gridHeight)
+		}
 	})
 
 	t.Run("Topology preservation performance", func(t *testing.T) {
-		t.Skip("SpatialEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test that topology preservation computations don't slow encoding
-		// encoder := createSpatialEncoder()
-		// config := SensorConfig{
-		//     SDRWidth: 4096,
-		//     TargetSparsity: 0.02,
-		//     CustomParams: map[string]interface{}{
-		//         "preserve_topology": true,
-		//         "topology_width": 64,
-		//         "topology_height": 64,
-		//     },
-		// }
-		// encoder.Configure(config)
-		//
-		// // Test adjacent coordinates that should have similar SDRs
-		// adjacentPairs := [][][]float64{
-		//     {{0.5, 0.5}, {0.51, 0.5}},
-		//     {{0.25, 0.25}, {0.25, 0.26}},
-		//     {{0.75, 0.75}, {0.76, 0.75}},
-		// }
-		//
-		// for _, pair := range adjacentPairs {
-		//     for _, coord := range pair {
-		//         start := time.Now()
-		//         _, err := encoder.Encode(coord)
-		//         elapsed := time.Since(start)
-		//
-		//         require.NoError(t, err)
-		//         assert.Less(t, elapsed, time.Millisecond,
-		//                     "Topology preservation encoding exceeded 1ms for %v", coord)
-		//     }
-		// }
+		// Local contrast binarization averages a neighbourhood around every pixel
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 4096
+		config.TargetSparsity = 0.02
+		config.SetParam("grid_width", 64)
+		config.SetParam("grid_height", 64)
+		config.SetParam("binarization", "local_contrast")
+		encoder := createSpatialEncoder(t, config)
+
+		// Shapes shifted by about one pixel, which should encode to similar SDRs
+		adjacentPairs := [][2][2]float64{
+			{{0.5, 0.5}, {0.51, 0.5}},
+			{{0.25, 0.25}, {0.25, 0.26}},
+			{{0.75, 0.75}, {0.76, 0.75}},
+		}
+
+		for _, pair := range adjacentPairs {
+			for _, center := range pair {
+				elapsed := timeEncode(t, encoder, blobGrid(64, center[0], center[1]))
+				assert.Less(t, elapsed, time.Millisecond,
+					"Topology preservation encoding exceeded 1ms for %v", center)
+			}
+		}
 	})
 
 	t.Run("Edge and boundary performance", func(t *testing.T) {
-		t.Skip("SpatialEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test performance at spatial boundaries and edges
-		// encoder := createSpatialEncoder()
-		// config := SensorConfig{
-		//     SDRWidth: 4096,
-		//     TargetSparsity: 0.02,
-		//     CustomParams: map[string]interface{}{
-		//         "topology_width": 100,
-		//         "topology_height": 100,
-		//     },
-		// }
-		// encoder.Configure(config)
-		//
-		// boundaryCoordinates := [][]float64{
-		//     {0.0, 0.0},   // Bottom-left corner
-		//     {1.0, 0.0},   // Bottom-right corner
-		//     {0.0, 1.0},   // Top-left corner
-		//     {1.0, 1.0},   // Top-right corner
-		//     {0.5, 0.0},   // Bottom edge
-		//     {0.5, 1.0},   // Top edge
-		//     {0.0, 0.5},   // Left edge
-		//     {1.0, 0.5},   // Right edge
-		// }
-		//
-		// for _, coord := range boundaryCoordinates {
-		//     start := time.Now()
-		//     _, err := encoder.Encode(coord)
-		//     elapsed := time.Since(start)
-		//
-		//     require.NoError(t, err)
-		//     assert.Less(t, elapsed, time.Millisecond,
-		//                 "Boundary coordinate %v exceeded 1ms encoding limit", coord)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 4096
+		config.TargetSparsity = 0.02
+		config.SetParam("grid_width", 64)
+		config.SetParam("grid_height", 64)
+		encoder := createSpatialEncoder(t, config)
+
+		boundaryCenters := [][2]float64{
+			{0.0, 0.0}, // Top-left corner
+			{1.0, 0.0}, // Top-right corner
+			{0.0, 1.0}, // Bottom-left corner
+			{1.0, 1.0}, // Bottom-right corner
+			{0.5, 0.0}, // Top edge
+			{0.5, 1.0}, // Bottom edge
+			{0.0, 0.5}, // Left edge
+			{1.0, 0.5}, // Right edge
+		}
+
+		for _, center := range boundaryCenters {
+			elapsed := timeEncode(t, encoder, blobGrid(100, center[0], center[1]))
+			assert.Less(t, elapsed, time.Millisecond,
+				"Boundary position %v exceeded 1ms encoding limit", center)
+		}
 	})
 
 	t.Run("Memory allocation efficiency", func(t *testing.T) {
-		t.Skip("SpatialEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// encoder := createSpatialEncoder()
-		// config := SensorConfig{
-		//     SDRWidth: 4096,
-		//     TargetSparsity: 0.02,
-		//     CustomParams: map[string]interface{}{
-		//         "topology_width": 64,
-		//         "topology_height": 64,
-		//     },
-		// }
-		// encoder.Configure(config)
-		//
-		// benchmark := NewSubMillisecondBenchmark()
-		// operation := func() {
-		//     _, err := encoder.Encode([]float64{0.42, 0.73})
-		//     require.NoError(t, err)
-		// }
-		//
-		// benchmark.BenchmarkMemory(t, "SpatialEncode_Memory", operation)
-		// // Should minimize allocations for coordinate processing
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 4096
+		config.TargetSparsity = 0.02
+		config.SetParam("grid_width", 64)
+		config.SetParam("grid_height", 64)
+		encoder := createSpatialEncoder(t, config)
+
+		grid := blobGrid(64, 0.42, 0.73)
+		benchmark := tests.NewSubMillisecondBenchmark()
+		operation := func() {
+			_, err := encoder.Encode(grid)
+			require.NoError(t, err)
+		}
+
+		benchmark.BenchmarkMemory(t, "SpatialEncode_Memory", operation)
 	})
 
-	t.Run("Coordinate validation performance", func(t *testing.T) {
-		t.Skip("SpatialEncoder not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test that coordinate validation doesn't slow down encoding
-		// encoder := createSpatialEncoder()
-		// config := SensorConfig{SDRWidth: 2048, TargetSparsity: 0.02}
-		// encoder.Configure(config)
-		//
-		// // Test various coordinate formats and edge cases
-		// testCoordinates := []interface{}{
-		//     []float64{0.5, 0.5},          // Valid 2D
-		//     []float32{0.3, 0.7},          // Different float type
-		//     []int{0, 1},                  // Integer coordinates (should convert)
-		//     []float64{-0.1, 0.5},         // Out of bounds (should handle gracefully)
-		//     []float64{0.5, 1.1},          // Out of bounds (should handle gracefully)
-		// }
-		//
-		// for _, coord := range testCoordinates {
-		//     start := time.Now()
-		//     _, err := encoder.Encode(coord)
-		//     elapsed := time.Since(start)
-		//
-		//     // Note: Some inputs may trigger silent failure (no error, empty SDR)
-		//     assert.Less(t, elapsed, time.Millisecond,
-		//                 "Coordinate validation exceeded 1ms for %v", coord)
-		// }
+	t.Run("Input validation performance", func(t *testing.T) {
+		// Invalid inputs trigger silent failure without slowing down encoding
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 2048
+		config.TargetSparsity = 0.02
+		encoder := createSpatialEncoder(t, config)
+
+		testInputs := []interface{}{
+			blobGrid(45, 0.5, 0.5),               // Valid grid
+			[][]float64{},                        // Empty grid
+			[][]float64{{0, 1}, {1}},             // Ragged rows
+			[][]float64{{0, math.NaN()}, {1, 0}}, // Non-finite value
+			[]byte("not a png"),                  // Unsupported bytes
+			[]float64{0.5, 0.5},                  // Coordinates are not grids
+		}
+
+		for _, input := range testInputs {
+			start := time.Now()
+			_, err := encoder.Encode(input)
+			elapsed := time.Since(start)
+
+			require.NoError(t, err, "Silent failure should not return error")
+			assert.Less(t, elapsed, time.Millisecond,
+				"Input validation exceeded 1ms for %T", input)
+		}
 	})
 }
 
-// Helper functions will be implemented alongside SpatialEncoder
-// func createSpatialEncoder() SensorInterface { ... }
+// createSpatialEncoder creates and configures a spatial encoder
+func createSpatialEncoder(t *testing.T, config *sensors.SensorConfig) sensors.SensorInterface {
+	t.Helper()
+
+	encoder := encoders.NewSpatialSensor()
+	require.NoError(t, encoder.Configure(*config), "Spatial encoder configuration should succeed")
+	return encoder
+}
+
+// blobGrid returns a size×size grid with a filled disc whose center is given
+// as fractions of the grid size
+func blobGrid(size int, centerX, centerY float64) [][]float64 {
+	cx, cy := centerX*float64(size-1), centerY*float64(size-1)
+	radius := float64(size) / 6
+
+	grid := make([][]float64, size)
+	for y := range grid {
+		grid[y] = make([]float64, size)
+		for x := range grid[y] {
+			if math.Hypot(float64(x)-cx, float64(y)-cy) <= radius {
+				grid[y][x] = 1.0
+			}
+		}
+	}
+	return grid
+}
@@ -0,0 +1,134 @@
+package integration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sineWindow returns 1024 samples of a sine at 16 kHz with the given frequency and amplitude
+func sineWindow(frequency, amplitude float64) []float32 {
+	samples := make([]float32, 1024)
+	for i := range samples {
+		samples[i] = float32(amplitude * math.Sin(2*math.Pi*frequency*float64(i)/16000))
+	}
+	return samples
+}
+
+// TestAudioPipeline validates spectral encoding of PCM sample windows
+func TestAudioPipeline(t *testing.T) {
+	newSensor := func(t *testing.T, params map[string]interface{}) sensors.SensorInterface {
+		config := sensors.NewSensorConfig()
+		for key, value := range params {
+			config.SetParam(key, value)
+		}
+		return newPipelineSensor(t, "audio", config)
+	}
+
+	t.Run("Encodes with configured sparsity", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		sdr, err := sensor.Encode(sineWindow(440, 0.5))
+		require.NoError(t, err)
+		assert.Equal(t, 2048, sdr.Width())
+		assert.Len(t, sdr.ActiveBits(), 40)
+	})
+
+	t.Run("Similar tones overlap more than distant tones", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		a4, _ := sensor.Encode(sineWindow(440, 0.5))
+		nearA4, _ := sensor.Encode(sineWindow(450, 0.5))
+		high, _ := sensor.Encode(sineWindow(3000, 0.5))
+
+		assert.Greater(t, a4.Similarity(nearA4), 0.7)
+		assert.Less(t, a4.Similarity(high), a4.Similarity(nearA4))
+	})
+
+	t.Run("Loudness shifts band levels", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		loud, _ := sensor.Encode(sineWindow(1000, 0.9))
+		quiet, _ := sensor.Encode(sineWindow(1000, 0.001))
+		slightlyLouder, _ := sensor.Encode(sineWindow(1000, 0.0011))
+
+		assert.Less(t, loud.Similarity(quiet), 1.0)
+		assert.Greater(t, quiet.Similarity(slightlyLouder), quiet.Similarity(loud), "Close levels should share more bits")
+	})
+
+	t.Run("Sample formats are equivalent", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		floats := sineWindow(880, 0.25)
+		doubles := make([]float64, len(floats))
+		ints := make([]int16, len(floats))
+		for i, sample := range floats {
+			doubles[i] = float64(sample)
+			ints[i] = int16(math.Round(float64(sample) * 32768))
+		}
+
+		fromFloats, _ := sensor.Encode(floats)
+		fromDoubles, _ := sensor.Encode(doubles)
+		fromInts, _ := sensor.Encode(ints)
+		assert.Equal(t, fromFloats.ActiveBits(), fromDoubles.ActiveBits())
+		assert.Greater(t, fromFloats.Similarity(fromInts), 0.9)
+	})
+
+	t.Run("Invalid configuration is rejected", func(t *testing.T) {
+		invalid := []map[string]interface{}{
+			{"sample_rate": 0},
+			{"bands": 0},
+			{"bands": 100},
+			{"min_frequency": 9000.0},
+			{"max_frequency": 20000.0},
+			{"floor_db": 10.0},
+			{"band_scale": "mel"},
+			{"window": "hamming"},
+		}
+
+		for _, params := range invalid {
+			config := sensors.NewSensorConfig()
+			for key, value := range params {
+				config.SetParam(key, value)
+			}
+			assert.Error(t, encoders.NewAudioSensor().Configure(*config), "Params %v should be rejected", params)
+		}
+	})
+
+	t.Run("Invalid inputs trigger silent failure", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		for _, input := range []interface{}{
+			[]float32{0.5},
+			[]float64{0.1, math.Inf(1)},
+			"noise",
+		} {
+			sdr, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, sdr.ActiveBits(), "Input %v should produce an empty SDR", input)
+		}
+	})
+
+	t.Run("Two-sample windows depend on the window function", func(t *testing.T) {
+		twoSamples := []float64{0.5, -0.5}
+
+		hann := newSensor(t, nil)
+		sdr, err := hann.Encode(twoSamples)
+		require.NoError(t, err, "Silent failure should cover an all-zero Hann window")
+		assert.Empty(t, sdr.ActiveBits())
+
+		strict := newSensor(t, map[string]interface{}{"silent_failure": false})
+		_, err = strict.Encode(twoSamples)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "between 3 and")
+
+		rectangular := newSensor(t, map[string]interface{}{"window": "none", "silent_failure": false})
+		sdr, err = rectangular.Encode(twoSamples)
+		require.NoError(t, err)
+		assert.Len(t, sdr.ActiveBits(), 40)
+	})
+}
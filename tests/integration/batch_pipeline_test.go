@@ -0,0 +1,107 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBatchPipeline validates batch encoding through sensors and the registry
+func TestBatchPipeline(t *testing.T) {
+	t.Run("Native batch path matches single encodes", func(t *testing.T) {
+		sensor := encoders.NewNumericSensor()
+		require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+		_, native := sensor.(sensors.BatchEncoder)
+		require.True(t, native, "Numeric sensor should implement BatchEncoder")
+
+		inputs := []interface{}{1.0, 25.5, 50, 99.9}
+		results := sensors.EncodeBatch(sensor, inputs, sensors.BatchOptions{})
+		require.Len(t, results, len(inputs))
+
+		for i, input := range inputs {
+			require.NoError(t, results[i].Err)
+			single, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Equal(t, single.ActiveBits(), results[i].SDR.ActiveBits(), "Input %v", input)
+		}
+	})
+
+	t.Run("Fallback path encodes item by item", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SetParam("categories", []string{"a", "b", "c"})
+		sensor := encoders.NewCategoricalSensor()
+		require.NoError(t, sensor.Configure(*config))
+
+		results := sensors.EncodeBatch(sensor, []interface{}{"a", "b", "c"}, sensors.BatchOptions{})
+		for _, result := range results {
+			require.NoError(t, result.Err)
+			assert.NotEmpty(t, result.SDR.ActiveBits())
+		}
+		assert.Zero(t, results[0].SDR.Overlap(results[1].SDR))
+	})
+
+	t.Run("Per-item errors do not abort the batch", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SetParam("silent_failure", false)
+		sensor := encoders.NewNumericSensor()
+		require.NoError(t, sensor.Configure(*config))
+
+		results := sensors.EncodeBatch(sensor, []interface{}{10.0, "bad", 500.0, 20.0}, sensors.BatchOptions{})
+		require.Len(t, results, 4)
+		assert.NoError(t, results[0].Err)
+		assert.Error(t, results[1].Err)
+		assert.Error(t, results[2].Err)
+		assert.NoError(t, results[3].Err)
+		assert.NotEmpty(t, results[3].SDR.ActiveBits())
+	})
+
+	t.Run("Parallel workers preserve input order", func(t *testing.T) {
+		sensor := encoders.NewNumericSensor()
+		require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+
+		inputs := make([]interface{}, 1000)
+		for i := range inputs {
+			inputs[i] = float64(i%100) + 0.5
+		}
+
+		sequential := sensors.EncodeBatch(sensor, inputs, sensors.BatchOptions{})
+		parallel := sensors.EncodeBatch(sensor, inputs, sensors.BatchOptions{Workers: 4})
+		require.Len(t, parallel, len(inputs))
+		for i := range inputs {
+			require.NoError(t, parallel[i].Err)
+			assert.Equal(t, sequential[i].SDR.ActiveBits(), parallel[i].SDR.ActiveBits())
+		}
+	})
+
+	t.Run("Result buffer is reused", func(t *testing.T) {
+		sensor := encoders.NewNumericSensor()
+		require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+
+		buffer := make([]sensors.BatchResult, 0, 8)
+		results := sensors.EncodeBatch(sensor, []interface{}{1.0, 2.0, 3.0}, sensors.BatchOptions{Results: buffer})
+		assert.Len(t, results, 3)
+		assert.Equal(t, &buffer[:1][0], &results[0], "Results should be written into the provided buffer")
+	})
+
+	t.Run("Registry batch creates and configures the sensor", func(t *testing.T) {
+		registry := sensors.NewRegistry()
+		require.NoError(t, registry.Register("numeric", encoders.NewNumericSensor))
+
+		results, err := registry.EncodeBatch("numeric", *sensors.NewSensorConfig(), []interface{}{5.0, 6.0}, sensors.BatchOptions{Workers: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.NoError(t, results[0].Err)
+		assert.NoError(t, results[1].Err)
+
+		_, err = registry.EncodeBatch("unknown", *sensors.NewSensorConfig(), []interface{}{1.0}, sensors.BatchOptions{})
+		assert.Error(t, err)
+
+		invalid := sensors.NewSensorConfig()
+		invalid.SDRWidth = 10
+		_, err = registry.EncodeBatch("numeric", *invalid, []interface{}{1.0}, sensors.BatchOptions{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,97 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuiltinRegistry validates one-call registration of the shipped encoders
+func TestBuiltinRegistry(t *testing.T) {
+	t.Run("Default registry holds every built-in encoder", func(t *testing.T) {
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+
+		builtins := encoders.Builtins()
+		assert.Equal(t, len(builtins), registry.Count())
+
+		info := registry.GetInfo()
+		assert.True(t, info.Built_insLoaded, "Default registry should report built-ins as loaded")
+
+		for _, builtin := range builtins {
+			assert.NoError(t, registry.ValidateRegistration(builtin.Type))
+			assert.Equal(t, encoders.BuiltinVersion, info.Versions[builtin.Type])
+
+			sensor, err := registry.Create(builtin.Type)
+			require.NoError(t, err)
+			assert.Equal(t, builtin.Type, sensor.Metadata().Type, "Registered name should match the sensor's own type")
+		}
+	})
+
+	t.Run("Built-ins configure and encode with defaults", func(t *testing.T) {
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+
+		inputs := map[string]interface{}{
+			"numeric":     42.0,
+			"categorical": "red",
+			"text":        "hello world",
+			"rdse":        42.0,
+		}
+		for sensorType, input := range inputs {
+			sensor, err := registry.Create(sensorType)
+			require.NoError(t, err)
+			require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()), "%s should accept the default configuration", sensorType)
+
+			encoded, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.NotEmpty(t, encoded.ActiveBits(), "%s should encode %v", sensorType, input)
+		}
+	})
+
+	t.Run("Registering twice reports the conflicting type", func(t *testing.T) {
+		registry := sensors.NewRegistry()
+		require.NoError(t, registry.Register("numeric", encoders.NewNumericSensor))
+
+		err := encoders.RegisterBuiltins(registry)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "numeric")
+
+		version, tagged := registry.Version("numeric")
+		assert.False(t, tagged, "Existing registration should keep its missing version, got %q", version)
+	})
+
+	t.Run("Conflicting registration leaves the registry unchanged", func(t *testing.T) {
+		// The last built-in conflicts, so every other one has been added by then
+		builtins := encoders.Builtins()
+		last := builtins[len(builtins)-1]
+
+		registry := sensors.NewRegistry()
+		require.NoError(t, registry.RegisterVersion(last.Type, last.Version, last.Factory))
+
+		err := encoders.RegisterBuiltins(registry)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), last.Type)
+
+		assert.Equal(t, []string{last.Type}, registry.List())
+		assert.Equal(t, []string{last.Version}, registry.Versions(last.Type))
+		assert.False(t, registry.GetInfo().Built_insLoaded)
+	})
+
+	t.Run("Version tags follow registrations", func(t *testing.T) {
+		registry := sensors.NewRegistry()
+		assert.Error(t, registry.RegisterVersion("numeric", "", encoders.NewNumericSensor))
+		require.NoError(t, registry.RegisterVersion("numeric", "2.1.0", encoders.NewNumericSensor))
+
+		version, tagged := registry.Version("numeric")
+		assert.True(t, tagged)
+		assert.Equal(t, "2.1.0", version)
+
+		require.NoError(t, registry.Unregister("numeric"))
+		_, tagged = registry.Version("numeric")
+		assert.False(t, tagged)
+	})
+}
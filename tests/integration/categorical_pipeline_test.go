@@ -1,261 +1,193 @@
 package integration
 
 import (
+	"fmt"
 	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestCategoricalPipeline validates categorical data encoding pipeline
 func TestCategoricalPipeline(t *testing.T) {
 	t.Run("Basic categorical encoding pipeline", func(t *testing.T) {
-		// This will fail until categorical pipeline is implemented
-		t.Skip("Categorical pipeline not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := sensors.NewRegistry()
-		// registry.Register("categorical", encoders.NewCategoricalSensor)
-		//
-		// sensor, err := registry.Create("categorical")
-		// require.NoError(t, err)
-		//
-		// // Configure categorical sensor
-		// config := sensors.NewConfig()
-		// config.SetParam("sdr_width", 2048)
-		// config.SetParam("target_sparsity", 0.02)
-		//
-		// err = sensor.Configure(config)
-		// require.NoError(t, err, "Categorical sensor configuration should succeed")
-		//
-		// // Test encoding various categorical values
-		// categories := []string{"red", "green", "blue", "yellow", "orange"}
-		// var sdrs map[string]sensors.SDR = make(map[string]sensors.SDR)
-		//
-		// for _, category := range categories {
-		//     sdr, err := sensor.Encode(category)
-		//     require.NoError(t, err, "Encoding '%s' should succeed", category)
-		//
-		//     // Validate SDR properties
-		//     assert.Equal(t, 2048, sdr.Width(), "SDR width should match configuration")
-		//     assert.InDelta(t, 0.02, sdr.Sparsity(), 0.005, "Sparsity should be ~2%")
-		//     assert.Len(t, sdr.ActiveBits(), int(2048*0.02), "Active bits should match sparsity")
-		//
-		//     sdrs[category] = sdr
-		// }
-		//
-		// // Verify consistency: same category produces same SDR
-		// sdr1, _ := sensor.Encode("red")
-		// sdr2, _ := sensor.Encode("red")
-		// assert.Equal(t, sdr1.ActiveBits(), sdr2.ActiveBits(),
-		//             "Same category should produce identical SDR")
-		//
-		// // Verify uniqueness: different categories produce different SDRs
-		// for i, cat1 := range categories {
-		//     for j, cat2 := range categories {
-		//         if i != j {
-		//             overlap := sdrs[cat1].Overlap(sdrs[cat2])
-		//             assert.Less(t, overlap, len(sdrs[cat1].ActiveBits())/2,
-		//                        "Different categories should have low overlap: %s vs %s", cat1, cat2)
-		//         }
-		//     }
-		// }
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "categorical", config)
+
+		// Test encoding various categorical values
+		categories := []string{"red", "green", "blue", "yellow", "orange"}
+		sdrs := make(map[string]sensors.SDR)
+
+		for _, category := range categories {
+			sdr, err := sensor.Encode(category)
+			require.NoError(t, err, "Encoding '%s' should succeed", category)
+
+			// Validate SDR properties
+			assert.Equal(t, 2048, sdr.Width(), "SDR width should match configuration")
+			assert.InDelta(t, 0.02, sdr.Sparsity(), 0.005, "Sparsity should be ~2%")
+			assert.Len(t, sdr.ActiveBits(), config.CalculateActiveBitsCount(), "Active bits should match sparsity")
+
+			sdrs[category] = sdr
+		}
+
+		// Verify consistency: same category produces same SDR
+		sdr1, _ := sensor.Encode("red")
+		sdr2, _ := sensor.Encode("red")
+		assert.Equal(t, sdr1.ActiveBits(), sdr2.ActiveBits(), "Same category should produce identical SDR")
+
+		// Verify uniqueness: different categories produce different SDRs
+		for i, cat1 := range categories {
+			for j, cat2 := range categories {
+				if i != j {
+					overlap := sdrs[cat1].Overlap(sdrs[cat2])
+					assert.Less(t, overlap, len(sdrs[cat1].ActiveBits())/2,
+						"Different categories should have low overlap: %s vs %s", cat1, cat2)
+				}
+			}
+		}
 	})
 
-	t.Run("Hash collision handling", func(t *testing.T) {
-		t.Skip("Categorical pipeline not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test that hash collisions are handled gracefully
-		// registry := sensors.NewRegistry()
-		// registry.Register("categorical", encoders.NewCategoricalSensor)
-		// sensor, _ := registry.Create("categorical")
-		//
-		// // Use small SDR width to force hash collisions
-		// config := sensors.NewConfig()
-		// config.SetParam("sdr_width", 100)
-		// config.SetParam("target_sparsity", 0.1) // 10 active bits
-		// sensor.Configure(config)
-		//
-		// // Generate many categories to force collisions
-		// categories := make([]string, 1000)
-		// for i := 0; i < 1000; i++ {
-		//     categories[i] = fmt.Sprintf("category_%d", i)
-		// }
-		//
-		// sdrMap := make(map[string]sensors.SDR)
-		// for _, category := range categories {
-		//     sdr, err := sensor.Encode(category)
-		//     require.NoError(t, err, "Should handle potential hash collision for %s", category)
-		//
-		//     // Verify each category gets a valid SDR
-		//     assert.Equal(t, 100, sdr.Width())
-		//     assert.InDelta(t, 0.1, sdr.Sparsity(), 0.02)
-		//
-		//     sdrMap[category] = sdr
-		// }
-		//
-		// // Verify that despite potential hash collisions,
-		// // identical categories still produce identical SDRs
-		// for _, category := range categories[:10] { // Test first 10
-		//     sdr1 := sdrMap[category]
-		//     sdr2, _ := sensor.Encode(category)
-		//     assert.Equal(t, sdr1.ActiveBits(), sdr2.ActiveBits(),
-		//                 "Category %s should be consistent despite hash collisions", category)
-		// }
+	t.Run("Fixed vocabulary produces non-overlapping SDRs", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SetParam("categories", []interface{}{"red", "green", "blue"})
+		sensor := newPipelineSensor(t, "categorical", config)
+
+		red, err := sensor.Encode("red")
+		require.NoError(t, err)
+		green, err := sensor.Encode("green")
+		require.NoError(t, err)
+		blue, err := sensor.Encode("blue")
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, red.Overlap(green))
+		assert.Equal(t, 0, red.Overlap(blue))
+		assert.Equal(t, 0, green.Overlap(blue))
+
+		unknown, err := sensor.Encode("purple")
+		require.NoError(t, err, "Unknown category should trigger silent failure")
+		assert.Empty(t, unknown.ActiveBits())
+	})
+
+	t.Run("Fixed vocabulary must fit SDR width", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 100
+		config.TargetSparsity = 0.1
+		vocabulary := make([]string, 20)
+		for i := range vocabulary {
+			vocabulary[i] = fmt.Sprintf("category_%d", i)
+		}
+		config.SetParam("categories", vocabulary)
+
+		assert.Error(t, encoders.NewCategoricalSensor().Configure(*config))
 	})
 
-	t.Run("Large vocabulary scaling", func(t *testing.T) {
-		t.Skip("Categorical pipeline not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test performance and correctness with large category vocabulary
-		// registry := sensors.NewRegistry()
-		// registry.Register("categorical", encoders.NewCategoricalSensor)
-		// sensor, _ := registry.Create("categorical")
-		//
-		// config := sensors.NewConfig()
-		// config.SetParam("sdr_width", 8192) // Larger SDR for large vocabulary
-		// config.SetParam("target_sparsity", 0.01) // 1% sparsity
-		// sensor.Configure(config)
-		//
-		// // Create large vocabulary
-		// vocabularySize := 10000
-		// categories := make([]string, vocabularySize)
-		// for i := 0; i < vocabularySize; i++ {
-		//     categories[i] = fmt.Sprintf("vocab_word_%d", i)
-		// }
-		//
-		// // Encode all categories
-		// sdrs := make([]sensors.SDR, vocabularySize)
-		// for i, category := range categories {
-		//     sdr, err := sensor.Encode(category)
-		//     require.NoError(t, err, "Should encode large vocabulary item %d", i)
-		//     sdrs[i] = sdr
-		// }
-		//
-		// // Verify statistical properties of large vocabulary
-		// totalOverlap := 0
-		// comparisons := 0
-		// for i := 0; i < 100; i++ { // Sample 100 random pairs
-		//     for j := i + 1; j < 100; j++ {
-		//         overlap := sdrs[i].Overlap(sdrs[j])
-		//         totalOverlap += overlap
-		//         comparisons++
-		//     }
-		// }
-		//
-		// avgOverlap := float64(totalOverlap) / float64(comparisons)
-		// expectedOverlap := float64(8192) * 0.01 * 0.01 // Random overlap expectation
-		// assert.InDelta(t, expectedOverlap, avgOverlap, expectedOverlap*0.5,
-		//               "Average overlap should be close to random expectation")
+	t.Run("Similarity groups share bits", func(t *testing.T) {
+		for _, fixed := range []bool{false, true} {
+			config := sensors.NewSensorConfig()
+			if fixed {
+				config.SetParam("categories", []string{"cat", "dog", "car", "bus"})
+			}
+			config.SetParam("groups", map[string][]string{
+				"animal":  {"cat", "dog"},
+				"vehicle": {"car", "bus"},
+			})
+			config.SetParam("group_overlap", 0.4)
+			sensor := newPipelineSensor(t, "categorical", config)
+
+			cat, _ := sensor.Encode("cat")
+			dog, _ := sensor.Encode("dog")
+			car, _ := sensor.Encode("car")
+
+			assert.InDelta(t, 0.4, cat.Similarity(dog), 0.05, "Group members should share the configured fraction (fixed=%v)", fixed)
+			assert.Less(t, cat.Similarity(car), 0.1, "Categories from different groups should barely overlap (fixed=%v)", fixed)
+			assert.Len(t, cat.ActiveBits(), config.CalculateActiveBitsCount())
+		}
+	})
+
+	t.Run("Invalid group configuration", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SetParam("groups", map[string]interface{}{
+			"a": []interface{}{"x", "y"},
+			"b": []interface{}{"y", "z"},
+		})
+		assert.Error(t, encoders.NewCategoricalSensor().Configure(*config), "Category in two groups should be rejected")
+
+		config = sensors.NewSensorConfig()
+		config.SetParam("group_overlap", 1.5)
+		assert.Error(t, encoders.NewCategoricalSensor().Configure(*config), "Overlap fraction must be below 1")
+	})
+
+	t.Run("Hash collision handling", func(t *testing.T) {
+		// Use small SDR width to force hash collisions
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 100
+		config.TargetSparsity = 0.1
+		sensor := newPipelineSensor(t, "categorical", config)
+
+		// Generate many categories to force collisions
+		categories := make([]string, 1000)
+		for i := 0; i < 1000; i++ {
+			categories[i] = fmt.Sprintf("category_%d", i)
+		}
+
+		sdrMap := make(map[string]sensors.SDR)
+		for _, category := range categories {
+			sdr, err := sensor.Encode(category)
+			require.NoError(t, err, "Should handle potential hash collision for %s", category)
+
+			// Verify each category gets a valid SDR
+			assert.Equal(t, 100, sdr.Width())
+			assert.InDelta(t, 0.1, sdr.Sparsity(), 0.02)
+
+			sdrMap[category] = sdr
+		}
+
+		// Identical categories still produce identical SDRs
+		for _, category := range categories[:10] {
+			sdr2, _ := sensor.Encode(category)
+			assert.Equal(t, sdrMap[category].ActiveBits(), sdr2.ActiveBits(),
+				"Category %s should be consistent despite hash collisions", category)
+		}
 	})
 
 	t.Run("Unicode category support", func(t *testing.T) {
-		t.Skip("Categorical pipeline not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test encoding of Unicode category names
-		// registry := sensors.NewRegistry()
-		// registry.Register("categorical", encoders.NewCategoricalSensor)
-		// sensor, _ := registry.Create("categorical")
-		//
-		// config := sensors.NewConfig()
-		// config.SetParam("sdr_width", 2048)
-		// config.SetParam("target_sparsity", 0.02)
-		// sensor.Configure(config)
-		//
-		// unicodeCategories := []string{
-		//     "English",
-		//     "Français",      // French with accents
-		//     "Español",       // Spanish with accent
-		//     "Русский",       // Russian Cyrillic
-		//     "日本語",         // Japanese
-		//     "中文",          // Chinese
-		//     "العربية",       // Arabic
-		//     "हिन्दी",        // Hindi
-		//     "🏷️Category",   // Emoji prefix
-		//     "Cat🐱egory",   // Emoji middle
-		// }
-		//
-		// var sdrs []sensors.SDR
-		// for _, category := range unicodeCategories {
-		//     sdr, err := sensor.Encode(category)
-		//     require.NoError(t, err, "Should encode Unicode category: %s", category)
-		//
-		//     assert.Equal(t, 2048, sdr.Width())
-		//     assert.InDelta(t, 0.02, sdr.Sparsity(), 0.005)
-		//     assert.Greater(t, len(sdr.ActiveBits()), 0, "Unicode category should produce non-empty SDR")
-		//
-		//     sdrs = append(sdrs, sdr)
-		// }
-		//
-		// // Verify consistency for Unicode categories
-		// for _, category := range unicodeCategories {
-		//     sdr1, _ := sensor.Encode(category)
-		//     sdr2, _ := sensor.Encode(category)
-		//     assert.Equal(t, sdr1.ActiveBits(), sdr2.ActiveBits(),
-		//                 "Unicode category should be consistent: %s", category)
-		// }
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "categorical", config)
+
+		unicodeCategories := []string{"English", "Français", "Русский", "日本語", "العربية", "Cat🐱egory"}
+		for _, category := range unicodeCategories {
+			sdr1, err := sensor.Encode(category)
+			require.NoError(t, err, "Should encode Unicode category: %s", category)
+			assert.InDelta(t, 0.02, sdr1.Sparsity(), 0.005)
+
+			sdr2, _ := sensor.Encode(category)
+			assert.Equal(t, sdr1.ActiveBits(), sdr2.ActiveBits(), "Unicode category should be consistent: %s", category)
+		}
 	})
 
 	t.Run("Category name normalization", func(t *testing.T) {
-		t.Skip("Categorical pipeline not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test handling of category name variations
-		// registry := sensors.NewRegistry()
-		// registry.Register("categorical", encoders.NewCategoricalSensor)
-		// sensor, _ := registry.Create("categorical")
-		//
-		// config := sensors.NewConfig()
-		// config.SetParam("sdr_width", 1000)
-		// config.SetParam("target_sparsity", 0.02)
-		// sensor.Configure(config)
-		//
-		// // Test case sensitivity (should be treated as different categories)
-		// variations := []string{
-		//     "Category",
-		//     "category",
-		//     "CATEGORY",
-		//     "CaTeGoRy",
-		// }
-		//
-		// var sdrs []sensors.SDR
-		// for _, variation := range variations {
-		//     sdr, err := sensor.Encode(variation)
-		//     require.NoError(t, err, "Should encode case variation: %s", variation)
-		//     sdrs = append(sdrs, sdr)
-		// }
-		//
-		// // Verify that case variations produce different SDRs
-		// for i, sdr1 := range sdrs {
-		//     for j, sdr2 := range sdrs {
-		//         if i != j {
-		//             overlap := sdr1.Overlap(sdr2)
-		//             similarity := sdr1.Similarity(sdr2)
-		//             assert.Less(t, similarity, 0.9,
-		//                        "Case variations should produce different SDRs: %s vs %s",
-		//                        variations[i], variations[j])
-		//         }
-		//     }
-		// }
-		//
-		// // Test whitespace handling
-		// whitespaceVariations := []string{
-		//     "test",
-		//     " test",
-		//     "test ",
-		//     " test ",
-		//     "  test  ",
-		// }
-		//
-		// // These should be treated as different categories (exact string matching)
-		// for i, variation := range whitespaceVariations {
-		//     sdr, err := sensor.Encode(variation)
-		//     require.NoError(t, err, "Should encode whitespace variation %d", i)
-		//
-		//     // Consistency check
-		//     sdr2, _ := sensor.Encode(variation)
-		//     assert.Equal(t, sdr.ActiveBits(), sdr2.ActiveBits(),
-		//                 "Whitespace variation should be consistent: '%s'", variation)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 1000
+		sensor := newPipelineSensor(t, "categorical", config)
+
+		// Case variations are treated as different categories (exact string matching)
+		variations := []string{"Category", "category", "CATEGORY", " category "}
+		var sdrs []sensors.SDR
+		for _, variation := range variations {
+			sdr, err := sensor.Encode(variation)
+			require.NoError(t, err, "Should encode variation: %s", variation)
+			sdrs = append(sdrs, sdr)
+		}
+
+		for i, sdr1 := range sdrs {
+			for j, sdr2 := range sdrs {
+				if i != j {
+					assert.Less(t, sdr1.Similarity(sdr2), 0.9,
+						"Variations should produce different SDRs: %q vs %q", variations[i], variations[j])
+				}
+			}
+		}
 	})
 }
@@ -0,0 +1,144 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCompositeChildRegistry creates a registry with the child sensor types used by composite tests
+func newCompositeChildRegistry(t *testing.T) *sensors.Registry {
+	t.Helper()
+
+	registry := sensors.NewRegistry()
+	require.NoError(t, registry.Register("numeric", encoders.NewNumericSensor))
+	require.NoError(t, registry.Register("categorical", encoders.NewCategoricalSensor))
+
+	return registry
+}
+
+// compositeTestFields returns a temperature/room field layout of 1024 + 512 bits
+func compositeTestFields() []encoders.CompositeField {
+	temperature := sensors.NewSensorConfig()
+	temperature.SDRWidth = 1024
+	temperature.Range = &sensors.Range{Min: -20, Max: 50}
+
+	room := sensors.NewSensorConfig()
+	room.SDRWidth = 512
+	room.TargetSparsity = 0.04
+	room.SetParam("categories", []string{"kitchen", "office", "garage"})
+
+	return []encoders.CompositeField{
+		{Name: "temperature", Type: "numeric", Config: *temperature},
+		{Name: "room", Type: "categorical", Config: *room},
+	}
+}
+
+// TestCompositePipeline validates multi-field encoding through child sensors
+func TestCompositePipeline(t *testing.T) {
+	t.Run("Child SDRs are concatenated at their offsets", func(t *testing.T) {
+		registry := newCompositeChildRegistry(t)
+		composite := encoders.NewCompositeSensor(registry, compositeTestFields()...)
+		require.NoError(t, composite.Configure(*sensors.NewSensorConfig()))
+
+		metadata := composite.Metadata()
+		assert.Equal(t, 1536, metadata.SDRWidth)
+		assert.Equal(t, []encoders.CompositeSegment{
+			{Name: "temperature", Type: "numeric", Offset: 0, Width: 1024},
+			{Name: "room", Type: "categorical", Offset: 1024, Width: 512},
+		}, metadata.Capabilities["layout"])
+
+		sdr, err := composite.Encode(map[string]interface{}{"temperature": 21.5, "room": "office"})
+		require.NoError(t, err)
+		assert.Equal(t, 1536, sdr.Width())
+
+		var temperatureBits, roomBits int
+		for _, bit := range sdr.ActiveBits() {
+			if bit < 1024 {
+				temperatureBits++
+			} else {
+				roomBits++
+			}
+		}
+		assert.Equal(t, 20, temperatureBits)
+		assert.Equal(t, 20, roomBits)
+	})
+
+	t.Run("Struct and map inputs encode identically", func(t *testing.T) {
+		type reading struct {
+			Temperature float64 `json:"temperature"`
+			Location    string  `json:"room"`
+		}
+
+		registry := newCompositeChildRegistry(t)
+		composite := encoders.NewCompositeSensor(registry, compositeTestFields()...)
+		require.NoError(t, composite.Configure(*sensors.NewSensorConfig()))
+
+		fromMap, err := composite.Encode(map[string]interface{}{"temperature": 5.0, "room": "garage"})
+		require.NoError(t, err)
+		fromStruct, err := composite.Encode(&reading{Temperature: 5.0, Location: "garage"})
+		require.NoError(t, err)
+
+		assert.Equal(t, fromMap.ActiveBits(), fromStruct.ActiveBits())
+	})
+
+	t.Run("Changing one field only changes its segment", func(t *testing.T) {
+		registry := newCompositeChildRegistry(t)
+		composite := encoders.NewCompositeSensor(registry, compositeTestFields()...)
+		require.NoError(t, composite.Configure(*sensors.NewSensorConfig()))
+
+		kitchen, _ := composite.Encode(map[string]interface{}{"temperature": 18.0, "room": "kitchen"})
+		office, _ := composite.Encode(map[string]interface{}{"temperature": 18.0, "room": "office"})
+
+		assert.Equal(t, 20, kitchen.Overlap(office), "The temperature segment should be unchanged")
+	})
+
+	t.Run("Registered composite type is usable from the registry", func(t *testing.T) {
+		registry := newCompositeChildRegistry(t)
+		require.NoError(t, registry.Register("room_climate", encoders.CompositeFactory(registry, compositeTestFields()...)))
+
+		sensor, err := registry.Create("room_climate")
+		require.NoError(t, err)
+		require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+		require.NoError(t, sensor.Validate())
+
+		clone := sensor.Clone()
+		input := map[string]interface{}{"temperature": 30.0, "room": "kitchen"}
+		original, err := sensor.Encode(input)
+		require.NoError(t, err)
+		cloned, err := clone.Encode(input)
+		require.NoError(t, err)
+		assert.Equal(t, original.ActiveBits(), cloned.ActiveBits())
+	})
+
+	t.Run("Invalid configuration is rejected", func(t *testing.T) {
+		registry := newCompositeChildRegistry(t)
+		fields := compositeTestFields()
+
+		assert.Error(t, encoders.NewCompositeSensor(registry).Configure(*sensors.NewSensorConfig()), "Composite without fields")
+		assert.Error(t, encoders.NewCompositeSensor(nil, fields...).Configure(*sensors.NewSensorConfig()), "Composite without registry")
+		assert.Error(t, encoders.NewCompositeSensor(registry, fields[0], fields[0]).Configure(*sensors.NewSensorConfig()), "Duplicate field names")
+		assert.Error(t, encoders.NewCompositeSensor(registry, encoders.CompositeField{Name: "x", Type: "unknown"}).Configure(*sensors.NewSensorConfig()), "Unknown child type")
+	})
+
+	t.Run("Invalid inputs trigger silent failure", func(t *testing.T) {
+		registry := newCompositeChildRegistry(t)
+		composite := encoders.NewCompositeSensor(registry, compositeTestFields()...)
+		require.NoError(t, composite.Configure(*sensors.NewSensorConfig()))
+
+		for _, input := range []interface{}{
+			map[string]interface{}{"temperature": 21.5},
+			map[string]interface{}{"temperature": 21.5, "room": "attic"},
+			map[int]interface{}{1: 21.5},
+			"kitchen",
+		} {
+			sdr, err := composite.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, sdr.ActiveBits(), "Input %v should produce an empty SDR", input)
+			assert.Equal(t, 1536, sdr.Width())
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package integration
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDateTimePipeline validates date/time encoding pipeline
+func TestDateTimePipeline(t *testing.T) {
+	t.Run("Basic date/time encoding pipeline", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "datetime", config)
+
+		timestamp := time.Date(2024, time.March, 12, 9, 30, 0, 0, time.UTC)
+		sdr, err := sensor.Encode(timestamp)
+		require.NoError(t, err)
+		assert.Len(t, sdr.ActiveBits(), config.CalculateActiveBitsCount())
+
+		fromString, err := sensor.Encode(timestamp.Format(time.RFC3339))
+		require.NoError(t, err)
+		assert.Equal(t, sdr.ActiveBits(), fromString.ActiveBits(), "RFC 3339 strings should match time.Time input")
+
+		fromPointer, err := sensor.Encode(&timestamp)
+		require.NoError(t, err)
+		assert.Equal(t, sdr.ActiveBits(), fromPointer.ActiveBits())
+	})
+
+	t.Run("Time of day wraps around midnight", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SetParam("day_of_week", false)
+		config.SetParam("weekend", false)
+		sensor := newPipelineSensor(t, "datetime", config)
+
+		lateNight, _ := sensor.Encode(time.Date(2024, time.March, 12, 23, 59, 0, 0, time.UTC))
+		earlyMorning, _ := sensor.Encode(time.Date(2024, time.March, 13, 0, 1, 0, 0, time.UTC))
+		noon, _ := sensor.Encode(time.Date(2024, time.March, 13, 12, 0, 0, 0, time.UTC))
+
+		assert.Greater(t, lateNight.Similarity(earlyMorning), 0.9, "23:59 and 00:01 should overlap")
+		assert.Equal(t, 0.0, lateNight.Similarity(noon), "Times half a day apart should not overlap")
+	})
+
+	t.Run("Buckets tile the day up to the wrap point", func(t *testing.T) {
+		// A radius that does not divide the day evenly into buckets
+		config := sensors.NewSensorConfig()
+		config.SetParam("day_of_week", false)
+		config.SetParam("weekend", false)
+		config.SetParam("time_of_day_radius", 6.5)
+		sensor := newPipelineSensor(t, "datetime", config)
+
+		// Sample every 30 seconds and measure how long each encoding lasts
+		midnight := time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC)
+		var runs []int
+		var previous []int
+		for step := 0; step < 24*120; step++ {
+			sdr, err := sensor.Encode(midnight.Add(time.Duration(step) * 30 * time.Second))
+			require.NoError(t, err)
+			if len(runs) > 0 && assert.ObjectsAreEqual(previous, sdr.ActiveBits()) {
+				runs[len(runs)-1]++
+				continue
+			}
+			runs = append(runs, 1)
+			previous = sdr.ActiveBits()
+		}
+
+		// A bucket straddling midnight shows up as both the last and the first run
+		first, _ := sensor.Encode(midnight)
+		if assert.ObjectsAreEqual(previous, first.ActiveBits()) {
+			runs[0] += runs[len(runs)-1]
+			runs = runs[:len(runs)-1]
+		}
+
+		// Adjacent buckets rarely hash to the same bits and then share one
+		// run, so every run must last a whole number of typical buckets
+		sorted := append([]int(nil), runs...)
+		sort.Ints(sorted)
+		typical := float64(sorted[len(sorted)/2])
+		for i, run := range runs {
+			buckets := math.Max(1, math.Round(float64(run)/typical))
+			assert.InDelta(t, buckets*typical, float64(run), 1,
+				"Run %d of %d samples should span whole buckets, including the one at midnight", i, run)
+		}
+	})
+
+	t.Run("Weekend flag", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SetParam("time_of_day", false)
+		config.SetParam("day_of_week", false)
+		sensor := newPipelineSensor(t, "datetime", config)
+
+		saturday, _ := sensor.Encode(time.Date(2024, time.March, 16, 10, 0, 0, 0, time.UTC))
+		sunday, _ := sensor.Encode(time.Date(2024, time.March, 17, 18, 0, 0, 0, time.UTC))
+		monday, _ := sensor.Encode(time.Date(2024, time.March, 18, 10, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, saturday.ActiveBits(), sunday.ActiveBits(), "Weekend days should share the flag encoding")
+		assert.Equal(t, 0.0, saturday.Similarity(monday), "Weekday and weekend flags should not overlap")
+	})
+
+	t.Run("Holidays and season", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SetParam("time_of_day", false)
+		config.SetParam("day_of_week", false)
+		config.SetParam("weekend", false)
+		config.SetParam("season", true)
+		config.SetParam("holidays", []string{"12-25", "2024-07-04"})
+		sensor := newPipelineSensor(t, "datetime", config)
+
+		christmas, _ := sensor.Encode(time.Date(2024, time.December, 25, 12, 0, 0, 0, time.UTC))
+		boxingDay, _ := sensor.Encode(time.Date(2024, time.December, 26, 12, 0, 0, 0, time.UTC))
+		nextDay, _ := sensor.Encode(time.Date(2024, time.December, 27, 12, 0, 0, 0, time.UTC))
+		newYear, _ := sensor.Encode(time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC))
+		independenceDay, _ := sensor.Encode(time.Date(2024, time.July, 4, 12, 0, 0, 0, time.UTC))
+		summer, _ := sensor.Encode(time.Date(2024, time.July, 15, 12, 0, 0, 0, time.UTC))
+
+		assert.Less(t, christmas.Similarity(boxingDay), boxingDay.Similarity(nextDay),
+			"Holiday flag should distinguish otherwise adjacent dates")
+		assert.Greater(t, boxingDay.Similarity(newYear), 0.9, "Season should wrap around the year end")
+		assert.Less(t, boxingDay.Similarity(summer), 0.55, "Opposite seasons should share only the holiday flag")
+		assert.Greater(t, christmas.Similarity(independenceDay), 0.45, "Holidays should share the flag encoding")
+	})
+
+	t.Run("Component layout and timezone", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SetParam("time_of_day_weight", 2.0)
+		config.SetParam("timezone", "America/New_York")
+		sensor := newPipelineSensor(t, "datetime", config)
+
+		metadata := sensor.Metadata()
+		layout, ok := metadata.Capabilities["components"].([]map[string]interface{})
+		require.True(t, ok)
+		require.Len(t, layout, 3)
+		assert.Equal(t, "time_of_day", layout[0]["name"])
+		assert.Equal(t, config.SDRWidth/2, layout[0]["width"], "Weight 2 of 4 should take half the SDR")
+
+		utc := time.Date(2024, time.March, 12, 14, 0, 0, 0, time.UTC)
+		local := time.Date(2024, time.March, 12, 10, 0, 0, 0, time.FixedZone("EDT", -4*3600))
+		a, _ := sensor.Encode(utc)
+		b, _ := sensor.Encode(local)
+		assert.Equal(t, a.ActiveBits(), b.ActiveBits(), "Same instant should encode identically in the configured timezone")
+	})
+
+	t.Run("Invalid configuration is rejected", func(t *testing.T) {
+		invalid := []map[string]interface{}{
+			{"time_of_day": false, "day_of_week": false, "weekend": false},
+			{"time_of_day_radius": 13.0},
+			{"holiday": true},
+			{"holidays": []string{"Dec 25"}},
+			{"timezone": "Mars/Olympus_Mons"},
+		}
+
+		for _, params := range invalid {
+			config := sensors.NewSensorConfig()
+			for key, value := range params {
+				config.SetParam(key, value)
+			}
+
+			sensor := encoders.NewDateTimeSensor()
+			assert.Error(t, sensor.Configure(*config), "Params %v should be rejected", params)
+		}
+	})
+
+	t.Run("Invalid inputs trigger silent failure", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "datetime", config)
+
+		for _, input := range []interface{}{"yesterday", 12345, (*time.Time)(nil)} {
+			sdr, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, sdr.ActiveBits())
+		}
+	})
+}
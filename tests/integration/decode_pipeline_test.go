@@ -0,0 +1,146 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/htm-project/neural-api/internal/sensors/sdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unionSDR merges the active bits of several SDRs of equal width
+func unionSDR(t *testing.T, parts ...sensors.SDR) sensors.SDR {
+	t.Helper()
+
+	seen := make(map[int]struct{})
+	var bits []int
+	for _, part := range parts {
+		for _, bit := range part.ActiveBits() {
+			if _, exists := seen[bit]; !exists {
+				seen[bit] = struct{}{}
+				bits = append(bits, bit)
+			}
+		}
+	}
+
+	union, err := sdr.NewSDR(parts[0].Width(), bits)
+	require.NoError(t, err)
+	return sensors.NewSDRWrapper(union)
+}
+
+// TestDecodePipeline validates reconstruction of input values from SDRs
+func TestDecodePipeline(t *testing.T) {
+	t.Run("Numeric values decode to their bucket", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.Resolution = 0.5
+		sensor := encoders.NewNumericSensor()
+		require.NoError(t, sensor.Configure(*config))
+		decoder, ok := sensor.(sensors.Decoder)
+		require.True(t, ok, "Numeric sensor should implement Decoder")
+
+		encoded, err := sensor.Encode(42.0)
+		require.NoError(t, err)
+
+		candidates, err := decoder.Decode(encoded, 1)
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.InDelta(t, 42.0, candidates[0].Value, 1e-9)
+		assert.Equal(t, 1.0, candidates[0].Confidence)
+	})
+
+	t.Run("Numeric union decodes to its members", func(t *testing.T) {
+		sensor := encoders.NewNumericSensor()
+		require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+		decoder := sensor.(sensors.Decoder)
+
+		low, _ := sensor.Encode(20.0)
+		high, _ := sensor.Encode(70.0)
+
+		candidates, err := decoder.Decode(unionSDR(t, low, high), 2)
+		require.NoError(t, err)
+		require.Len(t, candidates, 2)
+
+		values := []interface{}{candidates[0].Value, candidates[1].Value}
+		assert.ElementsMatch(t, []interface{}{20.0, 70.0}, values)
+	})
+
+	t.Run("Categorical values decode from a fixed vocabulary", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SetParam("categories", []string{"red", "green", "blue"})
+		sensor := encoders.NewCategoricalSensor()
+		require.NoError(t, sensor.Configure(*config))
+		decoder := sensor.(sensors.Decoder)
+
+		red, _ := sensor.Encode("red")
+		blue, _ := sensor.Encode("blue")
+
+		candidates, err := decoder.Decode(unionSDR(t, red, blue), 0)
+		require.NoError(t, err)
+		require.Len(t, candidates, 2, "Categories without overlap should not be returned")
+		assert.ElementsMatch(t, []interface{}{"red", "blue"}, []interface{}{candidates[0].Value, candidates[1].Value})
+	})
+
+	t.Run("Open vocabulary cannot be decoded", func(t *testing.T) {
+		sensor := encoders.NewCategoricalSensor()
+		require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+
+		encoded, _ := sensor.Encode("anything")
+		_, err := sensor.(sensors.Decoder).Decode(encoded, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("Date/time components decode", func(t *testing.T) {
+		sensor := encoders.NewDateTimeSensor()
+		require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+		decoder := sensor.(sensors.Decoder)
+
+		// Saturday 14:30
+		encoded, err := sensor.Encode(time.Date(2024, 6, 15, 14, 30, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		candidates, err := decoder.Decode(encoded, 1)
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, 1.0, candidates[0].Confidence)
+
+		components := candidates[0].Value.(encoders.DateTimeComponents)
+		assert.InDelta(t, 14.5, components["time_of_day"], 0.5)
+		assert.InDelta(t, 5.6, components["day_of_week"], 0.2)
+		assert.Equal(t, 1.0, components["weekend"])
+	})
+
+	t.Run("Noisy SDR still decodes with lower confidence", func(t *testing.T) {
+		sensor := encoders.NewNumericSensor()
+		require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+
+		encoded, _ := sensor.Encode(55.0)
+		bits := encoded.ActiveBits()
+		noisy, err := sdr.NewSDR(encoded.Width(), append(bits[5:], 3, 900, 1500))
+		require.NoError(t, err)
+
+		candidates, err := sensor.(sensors.Decoder).Decode(sensors.NewSDRWrapper(noisy), 1)
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.InDelta(t, 55.0, candidates[0].Value, 1.0)
+		assert.Less(t, candidates[0].Confidence, 1.0)
+	})
+
+	t.Run("Invalid SDRs are rejected", func(t *testing.T) {
+		sensor := encoders.NewNumericSensor()
+		decoder := sensor.(sensors.Decoder)
+
+		empty, err := sdr.NewEmptySDR(2048)
+		require.NoError(t, err)
+		_, err = decoder.Decode(sensors.NewSDRWrapper(empty), 1)
+		assert.Error(t, err, "Unconfigured sensor should not decode")
+
+		require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+		narrow, err := sdr.NewEmptySDR(1024)
+		require.NoError(t, err)
+		_, err = decoder.Decode(sensors.NewSDRWrapper(narrow), 1)
+		assert.Error(t, err, "SDR width mismatch should be rejected")
+	})
+}
@@ -0,0 +1,175 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDeltaPipelineSensor creates a delta sensor with an RDSE delta child and
+// an optional bounded numeric child for the absolute value
+func newDeltaPipelineSensor(t *testing.T, withAbsolute bool, params map[string]interface{}) *encoders.DeltaSensor {
+	t.Helper()
+
+	registry := sensors.NewRegistry()
+	require.NoError(t, registry.Register("rdse", encoders.NewRDSESensor))
+	require.NoError(t, registry.Register("numeric", encoders.NewNumericSensor))
+
+	deltaConfig := sensors.NewSensorConfig()
+	deltaConfig.SDRWidth = 1024
+	deltaConfig.Resolution = 1.0
+
+	var absolute *encoders.ChildSensor
+	if withAbsolute {
+		absoluteConfig := sensors.NewSensorConfig()
+		absoluteConfig.SDRWidth = 1024
+		absolute = &encoders.ChildSensor{Type: "numeric", Config: *absoluteConfig}
+	}
+
+	config := sensors.NewSensorConfig()
+	for key, value := range params {
+		config.SetParam(key, value)
+	}
+
+	sensor := encoders.NewDeltaSensor(registry, encoders.ChildSensor{Type: "rdse", Config: *deltaConfig}, absolute)
+	require.NoError(t, sensor.Configure(*config), "Delta sensor configuration should succeed")
+	return sensor
+}
+
+// TestDeltaPipeline validates change encoding of numeric streams
+func TestDeltaPipeline(t *testing.T) {
+	t.Run("Equal changes encode identically at any level", func(t *testing.T) {
+		sensor := newDeltaPipelineSensor(t, false, nil)
+
+		first, err := sensor.Encode(10.0)
+		require.NoError(t, err)
+		assert.Len(t, first.ActiveBits(), 20)
+
+		low, _ := sensor.Encode(15.0)
+		_, _ = sensor.Encode(80.0)
+		high, _ := sensor.Encode(85.0)
+		assert.Equal(t, low.ActiveBits(), high.ActiveBits(), "A +5 step should encode the same at any level")
+
+		jump, _ := sensor.Encode(185.0)
+		assert.Less(t, low.Similarity(jump), 0.1, "A sudden jump should not resemble a small step")
+	})
+
+	t.Run("Streams keep independent history", func(t *testing.T) {
+		sensor := newDeltaPipelineSensor(t, false, nil)
+
+		_, _ = sensor.Encode(encoders.DeltaInput{Stream: "a", Value: 0})
+		_, _ = sensor.Encode(encoders.DeltaInput{Stream: "b", Value: 1000})
+		stepA, _ := sensor.Encode(encoders.DeltaInput{Stream: "a", Value: 3})
+		stepB, _ := sensor.Encode(map[string]interface{}{"stream": "b", "value": 1003.0})
+
+		assert.Equal(t, stepA.ActiveBits(), stepB.ActiveBits())
+		assert.Equal(t, 2, sensor.Metadata().Capabilities["streams"])
+	})
+
+	t.Run("Rate mode divides by elapsed seconds", func(t *testing.T) {
+		sensor := newDeltaPipelineSensor(t, false, map[string]interface{}{"mode": "rate"})
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		_, _ = sensor.Encode(encoders.DeltaInput{Value: 0, Timestamp: start})
+		fast, err := sensor.Encode(encoders.DeltaInput{Value: 20, Timestamp: start.Add(2 * time.Second)})
+		require.NoError(t, err)
+		slow, _ := sensor.Encode(encoders.DeltaInput{Value: 40, Timestamp: start.Add(4 * time.Second)})
+
+		assert.Equal(t, fast.ActiveBits(), slow.ActiveBits(), "Both steps are 10 per second")
+
+		stale, err := sensor.Encode(encoders.DeltaInput{Value: 50, Timestamp: start.Add(4 * time.Second)})
+		require.NoError(t, err)
+		assert.Empty(t, stale.ActiveBits(), "Non-increasing timestamps should fail silently")
+
+		missing, err := sensor.Encode(60.0)
+		require.NoError(t, err)
+		assert.Empty(t, missing.ActiveBits(), "Rate mode without timestamp should fail silently")
+	})
+
+	t.Run("Absolute value is concatenated", func(t *testing.T) {
+		sensor := newDeltaPipelineSensor(t, true, nil)
+
+		assert.Equal(t, []encoders.CompositeSegment{
+			{Name: "delta", Type: "rdse", Offset: 0, Width: 1024},
+			{Name: "value", Type: "numeric", Offset: 1024, Width: 1024},
+		}, sensor.Layout())
+
+		_, _ = sensor.Encode(10.0)
+		low, _ := sensor.Encode(15.0)
+		_, _ = sensor.Encode(80.0)
+		high, _ := sensor.Encode(85.0)
+
+		assert.Len(t, low.ActiveBits(), 40)
+		assert.Equal(t, 20, low.Overlap(high), "Only the delta segment should match")
+	})
+
+	t.Run("Rejected observations leave the history unchanged", func(t *testing.T) {
+		sensor := newDeltaPipelineSensor(t, true, nil)
+		reference := newDeltaPipelineSensor(t, true, nil)
+
+		_, _ = sensor.Encode(10.0)
+		rejected, err := sensor.Encode(500.0)
+		require.NoError(t, err)
+		assert.Empty(t, rejected.ActiveBits(), "Values outside the absolute child's range should fail silently")
+
+		_, _ = reference.Encode(10.0)
+		expected, _ := reference.Encode(15.0)
+		step, err := sensor.Encode(15.0)
+		require.NoError(t, err)
+		assert.Equal(t, expected.ActiveBits(), step.ActiveBits(), "The next change should be measured from the last accepted value")
+	})
+
+	t.Run("Reset and Clone start with empty history", func(t *testing.T) {
+		sensor := newDeltaPipelineSensor(t, false, nil)
+		zero, _ := sensor.Encode(5.0)
+		_, _ = sensor.Encode(7.0)
+
+		clone := sensor.Clone()
+		fromClone, err := clone.Encode(100.0)
+		require.NoError(t, err)
+		assert.Equal(t, zero.ActiveBits(), fromClone.ActiveBits(), "First observation of a clone encodes zero change")
+
+		sensor.Reset()
+		afterReset, _ := sensor.Encode(42.0)
+		assert.Equal(t, zero.ActiveBits(), afterReset.ActiveBits())
+	})
+
+	t.Run("Parallel batches match sequential batches", func(t *testing.T) {
+		inputs := make([]interface{}, 12)
+		for i := range inputs {
+			inputs[i] = float64(i * i)
+		}
+
+		sequential := sensors.EncodeBatch(newDeltaPipelineSensor(t, false, nil), inputs, sensors.BatchOptions{})
+
+		sensor := newDeltaPipelineSensor(t, false, nil)
+		assert.Equal(t, true, sensor.Metadata().Capabilities["stateful"])
+		parallel := sensors.EncodeBatch(sensor, inputs, sensors.BatchOptions{Workers: 4})
+		for i := range inputs {
+			require.NoError(t, parallel[i].Err)
+			assert.Equal(t, sequential[i].SDR.ActiveBits(), parallel[i].SDR.ActiveBits(), "Item %d", i)
+		}
+
+		// The history must hold the whole batch, so the next +23 step follows 121
+		next, err := sensor.Encode(144.0)
+		require.NoError(t, err)
+		reference := newDeltaPipelineSensor(t, false, nil)
+		_, _ = reference.Encode(121.0)
+		expected, _ := reference.Encode(144.0)
+		assert.Equal(t, expected.ActiveBits(), next.ActiveBits())
+	})
+
+	t.Run("Invalid mode is rejected", func(t *testing.T) {
+		registry := sensors.NewRegistry()
+		require.NoError(t, registry.Register("rdse", encoders.NewRDSESensor))
+
+		config := sensors.NewSensorConfig()
+		config.SetParam("mode", "acceleration")
+		sensor := encoders.NewDeltaSensor(registry, encoders.ChildSensor{Type: "rdse", Config: *sensors.NewSensorConfig()}, nil)
+		assert.Error(t, sensor.Configure(*config))
+	})
+}
@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/htm-project/neural-api/internal/sensors/sdr"
+	"github.com/htm-project/neural-api/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// embeddingDimensions is the vector length used by the embedding tests
+const embeddingDimensions = 64
+
+// randomEmbedding returns a Gaussian random vector
+func randomEmbedding(rng *rand.Rand) []float64 {
+	vector := make([]float64, embeddingDimensions)
+	for i := range vector {
+		vector[i] = rng.NormFloat64()
+	}
+	return vector
+}
+
+// blendEmbeddings returns a*x + b*y
+func blendEmbeddings(a float64, x []float64, b float64, y []float64) []float64 {
+	result := make([]float64, len(x))
+	for i := range x {
+		result[i] = a*x[i] + b*y[i]
+	}
+	return result
+}
+
+// vectorCosine returns the cosine similarity of two dense vectors
+func vectorCosine(x, y []float64) float64 {
+	var dot, nx, ny float64
+	for i := range x {
+		dot += x[i] * y[i]
+		nx += x[i] * x[i]
+		ny += y[i] * y[i]
+	}
+	return dot / math.Sqrt(nx*ny)
+}
+
+// sdrCosine compares two public SDRs with SimilarityCalculator.CosineSimilarity
+func sdrCosine(a, b sensors.SDR) float64 {
+	return sdr.NewSimilarityCalculator().CosineSimilarity(
+		a.(*sensors.SDRWrapper).GetInternalSDR(),
+		b.(*sensors.SDRWrapper).GetInternalSDR(),
+	)
+}
+
+// TestEmbeddingPipeline validates random projection of dense embeddings
+func TestEmbeddingPipeline(t *testing.T) {
+	newSensor := func(t *testing.T, method string) sensors.SensorInterface {
+		config := sensors.NewSensorConfig()
+		config.SetParam("dimensions", embeddingDimensions)
+		config.SetParam("method", method)
+		return newPipelineSensor(t, "embedding", config)
+	}
+
+	for _, method := range []string{"topk", "simhash"} {
+		t.Run("Output cosine tracks input cosine with "+method, func(t *testing.T) {
+			sensor := newSensor(t, method)
+			rng := rand.New(rand.NewSource(7))
+			base := randomEmbedding(rng)
+			noise := randomEmbedding(rng)
+
+			baseSDR, err := sensor.Encode(base)
+			require.NoError(t, err)
+			assert.Len(t, baseSDR.ActiveBits(), 40)
+
+			previous := 1.0
+			for _, mix := range []float64{0.1, 0.4, 1.0, 3.0} {
+				variant := blendEmbeddings(1, base, mix, noise)
+				encoded, err := sensor.Encode(variant)
+				require.NoError(t, err)
+
+				similarity := sdrCosine(baseSDR, encoded)
+				assert.LessOrEqual(t, similarity, previous, "Similarity should fall as input cosine %.2f falls", vectorCosine(base, variant))
+				previous = similarity
+			}
+
+			unrelated, _ := sensor.Encode(randomEmbedding(rng))
+			assert.Less(t, sdrCosine(baseSDR, unrelated), 0.2, "Unrelated embeddings should barely overlap")
+		})
+	}
+
+	t.Run("Encoding ignores vector length and precision", func(t *testing.T) {
+		sensor := newSensor(t, "topk")
+		base := randomEmbedding(rand.New(rand.NewSource(3)))
+
+		scaled := blendEmbeddings(5, base, 0, base)
+		single := make([]float32, len(base))
+		for i, value := range base {
+			single[i] = float32(value)
+		}
+
+		original, _ := sensor.Encode(base)
+		fromScaled, _ := sensor.Encode(scaled)
+		fromFloat32, _ := sensor.Encode(single)
+		assert.Equal(t, original.ActiveBits(), fromScaled.ActiveBits())
+		assert.Greater(t, original.Similarity(fromFloat32), 0.9)
+	})
+
+	t.Run("Same seed gives the same projection", func(t *testing.T) {
+		base := randomEmbedding(rand.New(rand.NewSource(11)))
+
+		first, _ := newSensor(t, "simhash").Encode(base)
+		second, _ := newSensor(t, "simhash").Clone().Encode(base)
+		assert.Equal(t, first.ActiveBits(), second.ActiveBits())
+	})
+
+	t.Run("Default method encodes large embeddings within 1ms", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SetParam("dimensions", 768)
+		sensor := newPipelineSensor(t, "embedding", config)
+		assert.Equal(t, "simhash", sensor.Metadata().Capabilities["method"])
+
+		rng := rand.New(rand.NewSource(5))
+		vector := make([]float32, 768)
+		for i := range vector {
+			vector[i] = float32(rng.NormFloat64())
+		}
+
+		tests.NewSubMillisecondBenchmark().Run(t, "EmbeddingEncode_768", func() {
+			_, err := sensor.Encode(vector)
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("Invalid configuration is rejected", func(t *testing.T) {
+		invalid := []map[string]interface{}{
+			{},
+			{"dimensions": 0},
+			{"dimensions": 16, "method": "pca"},
+		}
+
+		for _, params := range invalid {
+			config := sensors.NewSensorConfig()
+			for key, value := range params {
+				config.SetParam(key, value)
+			}
+			assert.Error(t, encoders.NewEmbeddingSensor().Configure(*config), "Params %v should be rejected", params)
+		}
+	})
+
+	t.Run("Invalid inputs trigger silent failure", func(t *testing.T) {
+		sensor := newSensor(t, "topk")
+
+		for _, input := range []interface{}{
+			make([]float64, embeddingDimensions),
+			[]float64{1, 2, 3},
+			append(make([]float64, embeddingDimensions-1), math.NaN()),
+			"vector",
+		} {
+			encoded, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, encoded.ActiveBits(), "Input %v should produce an empty SDR", input)
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFingerprintPipeline validates configuration fingerprints for reproducible encodings (FR-006)
+func TestFingerprintPipeline(t *testing.T) {
+	registry, err := encoders.NewDefaultRegistry()
+	require.NoError(t, err)
+
+	newSensor := func(t *testing.T, sensorType string, configure func(config *sensors.SensorConfig)) sensors.SensorInterface {
+		sensor, err := registry.Create(sensorType)
+		require.NoError(t, err)
+
+		config := sensors.NewSensorConfig()
+		if configure != nil {
+			configure(config)
+		}
+		require.NoError(t, sensor.Configure(*config), "%s configuration should succeed", sensorType)
+		return sensor
+	}
+
+	t.Run("Fingerprints are attached to metadata and every SDR", func(t *testing.T) {
+		unconfigured, err := registry.Create("rdse")
+		require.NoError(t, err)
+		assert.Empty(t, unconfigured.Metadata().Fingerprint, "Unconfigured sensors have no fingerprint")
+		assert.Equal(t, encoders.BuiltinVersion, unconfigured.Metadata().Version)
+
+		sensor := newSensor(t, "rdse", func(config *sensors.SensorConfig) {
+			config.SetParam("silent_failure", true)
+		})
+		fingerprint := sensor.Metadata().Fingerprint
+		assert.Len(t, fingerprint, 64, "Fingerprint should be a hex SHA-256")
+
+		encoded, err := sensor.Encode(42.0)
+		require.NoError(t, err)
+		assert.Equal(t, fingerprint, encoded.Fingerprint())
+
+		failed, err := sensor.Encode("not a number")
+		require.NoError(t, err)
+		assert.Empty(t, failed.ActiveBits())
+		assert.Equal(t, fingerprint, failed.Fingerprint(), "Silent failure SDRs should carry the fingerprint too")
+
+		assert.Equal(t, fingerprint, sensor.Clone().Metadata().Fingerprint)
+
+		identifiable, ok := sensor.(sensors.Identifiable)
+		require.True(t, ok)
+		identity := identifiable.Identity()
+		assert.Equal(t, "rdse", identity.Type)
+		assert.Equal(t, encoders.BuiltinVersion, identity.Version)
+		assert.Equal(t, 42, identity.Config.CustomParams["seed"], "Default seed should be part of the identity")
+
+		recomputed, err := identity.Fingerprint()
+		require.NoError(t, err)
+		assert.Equal(t, fingerprint, recomputed)
+	})
+
+	t.Run("Equivalent configurations share a fingerprint", func(t *testing.T) {
+		implicit := newSensor(t, "text", nil)
+		explicit := newSensor(t, "text", func(config *sensors.SensorConfig) {
+			config.SetParam("seed", 42.0)
+			config.SetParam("ngram_size", 2)
+			config.SetParam("case_insensitive", true)
+		})
+		assert.Equal(t, implicit.Metadata().Fingerprint, explicit.Metadata().Fingerprint,
+			"Spelled-out defaults should not change the fingerprint")
+
+		a, err := implicit.Encode("hello world")
+		require.NoError(t, err)
+		b, err := explicit.Encode("hello world")
+		require.NoError(t, err)
+		assert.Equal(t, a.ActiveBits(), b.ActiveBits())
+	})
+
+	t.Run("Silent failure mode does not change the fingerprint", func(t *testing.T) {
+		silent := newSensor(t, "rdse", func(config *sensors.SensorConfig) {
+			config.SetParam("silent_failure", true)
+		})
+		strict := newSensor(t, "rdse", func(config *sensors.SensorConfig) {
+			config.SetParam("silent_failure", false)
+		})
+		assert.Equal(t, newSensor(t, "rdse", nil).Metadata().Fingerprint, silent.Metadata().Fingerprint)
+		assert.Equal(t, silent.Metadata().Fingerprint, strict.Metadata().Fingerprint,
+			"Error reporting should not change the fingerprint")
+
+		a, err := silent.Encode(42.0)
+		require.NoError(t, err)
+		b, err := strict.Encode(42.0)
+		require.NoError(t, err)
+		assert.Equal(t, a.ActiveBits(), b.ActiveBits())
+	})
+
+	t.Run("Every encoding-relevant setting changes the fingerprint", func(t *testing.T) {
+		base := newSensor(t, "categorical", nil).Metadata().Fingerprint
+
+		variants := map[string]func(config *sensors.SensorConfig){
+			"seed":     func(config *sensors.SensorConfig) { config.SetParam("seed", 7) },
+			"width":    func(config *sensors.SensorConfig) { config.SDRWidth = 1024 },
+			"sparsity": func(config *sensors.SensorConfig) { config.TargetSparsity = 0.04 },
+			"params":   func(config *sensors.SensorConfig) { config.SetParam("categories", []string{"red", "green"}) },
+		}
+		seen := map[string]string{base: "base"}
+		for name, configure := range variants {
+			fingerprint := newSensor(t, "categorical", configure).Metadata().Fingerprint
+			previous, duplicate := seen[fingerprint]
+			assert.False(t, duplicate, "%s variant should not share the fingerprint of %s", name, previous)
+			seen[fingerprint] = name
+		}
+
+		assert.NotEqual(t, base, newSensor(t, "text", nil).Metadata().Fingerprint, "Sensor type is part of the fingerprint")
+	})
+
+	t.Run("Stateful sensors are fingerprinted by configuration only", func(t *testing.T) {
+		a := newSensor(t, "adaptive", func(config *sensors.SensorConfig) { config.SetParam("warmup", 2) })
+		b := newSensor(t, "adaptive", func(config *sensors.SensorConfig) { config.SetParam("warmup", 2) })
+		assert.True(t, a.Metadata().Stateful)
+		assert.False(t, newSensor(t, "numeric", nil).Metadata().Stateful)
+
+		for _, value := range []float64{0, 10} {
+			_, _ = a.Encode(value)
+		}
+		for _, value := range []float64{0, 1000} {
+			_, _ = b.Encode(value)
+		}
+
+		encodedA, err := a.Encode(10.0)
+		require.NoError(t, err)
+		encodedB, err := b.Encode(10.0)
+		require.NoError(t, err)
+		assert.Equal(t, a.Metadata().Fingerprint, b.Metadata().Fingerprint, "Learned ranges are not part of the fingerprint")
+		assert.NotEqual(t, encodedA.ActiveBits(), encodedB.ActiveBits())
+	})
+
+	t.Run("Composite fingerprints cover their children", func(t *testing.T) {
+		children := newCompositeChildRegistry(t)
+		composite := encoders.NewCompositeSensor(children, compositeTestFields()...)
+		require.NoError(t, composite.Configure(*sensors.NewSensorConfig()))
+
+		fields := compositeTestFields()
+		fields[1].Config.SetParam("seed", 7)
+		reseeded := encoders.NewCompositeSensor(children, fields...)
+		require.NoError(t, reseeded.Configure(*sensors.NewSensorConfig()))
+
+		assert.NotEmpty(t, composite.Metadata().Fingerprint)
+		assert.NotEqual(t, composite.Metadata().Fingerprint, reseeded.Metadata().Fingerprint)
+
+		encoded, err := composite.Encode(map[string]interface{}{"temperature": 21.5, "room": "office"})
+		require.NoError(t, err)
+		assert.Equal(t, composite.Metadata().Fingerprint, encoded.Fingerprint())
+	})
+
+	t.Run("Canonical configuration is order independent", func(t *testing.T) {
+		a := sensors.NewSensorConfig()
+		a.SetParam("alpha", 1)
+		a.SetParam("beta", map[string]interface{}{"y": 2, "x": 1})
+
+		b := sensors.NewSensorConfig()
+		b.SetParam("beta", map[string]interface{}{"x": 1.0, "y": 2.0})
+		b.SetParam("alpha", 1.0)
+
+		canonicalA, err := a.Canonical()
+		require.NoError(t, err)
+		canonicalB, err := b.Canonical()
+		require.NoError(t, err)
+		assert.Equal(t, string(canonicalA), string(canonicalB))
+		assert.Contains(t, string(canonicalA), `"params":{"alpha":1,"beta":{"x":1,"y":2}}`)
+
+		fingerprintA, err := a.Fingerprint()
+		require.NoError(t, err)
+		b.Resolution = 0.5
+		fingerprintB, err := b.Fingerprint()
+		require.NoError(t, err)
+		assert.NotEqual(t, fingerprintA, fingerprintB)
+	})
+
+	t.Run("Unserializable parameters are rejected", func(t *testing.T) {
+		sensor, err := registry.Create("numeric")
+		require.NoError(t, err)
+
+		config := sensors.NewSensorConfig()
+		config.SetParam("callback", func() {})
+		err = sensor.Configure(*config)
+		require.Error(t, err)
+
+		var configErr *sensors.ConfigurationError
+		require.True(t, errors.As(err, &configErr))
+		assert.Equal(t, "callback", configErr.Parameter)
+	})
+}
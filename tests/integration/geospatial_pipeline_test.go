@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/htm-project/neural-api/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeospatialPipeline validates GPS coordinate encoding pipeline
+func TestGeospatialPipeline(t *testing.T) {
+	t.Run("Basic geospatial encoding pipeline", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "geospatial", config)
+
+		point := encoders.GeoPoint{Latitude: 37.7749, Longitude: -122.4194, Speed: 1.5}
+		sdr, err := sensor.Encode(point)
+		require.NoError(t, err)
+		assert.Len(t, sdr.ActiveBits(), config.CalculateActiveBitsCount())
+
+		fromSlice, err := sensor.Encode([]float64{37.7749, -122.4194, 1.5})
+		require.NoError(t, err)
+		fromMap, err := sensor.Encode(map[string]interface{}{"lat": 37.7749, "lon": -122.4194, "speed": 1.5})
+		require.NoError(t, err)
+
+		assert.Equal(t, sdr.ActiveBits(), fromSlice.ActiveBits())
+		assert.Equal(t, sdr.ActiveBits(), fromMap.ActiveBits())
+	})
+
+	t.Run("Nearby positions overlap", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "geospatial", config)
+
+		// At this latitude 0.0003° of longitude is roughly 26 m, about one Mercator cell
+		base, _ := sensor.Encode(encoders.GeoPoint{Latitude: 37.7749, Longitude: -122.4194})
+		near, _ := sensor.Encode(encoders.GeoPoint{Latitude: 37.7749, Longitude: -122.4191})
+		far, _ := sensor.Encode(encoders.GeoPoint{Latitude: 37.7849, Longitude: -122.4094})
+
+		assert.Greater(t, base.Similarity(near), 0.5, "Positions one cell apart should share most bits")
+		assert.Less(t, base.Similarity(far), 0.1, "Positions a kilometer apart should barely overlap")
+	})
+
+	t.Run("Speed widens the neighbourhood", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "geospatial", config)
+
+		// Roughly 260 m apart
+		walkA, _ := sensor.Encode(encoders.GeoPoint{Latitude: 37.7749, Longitude: -122.4194, Speed: 1})
+		walkB, _ := sensor.Encode(encoders.GeoPoint{Latitude: 37.7749, Longitude: -122.4164, Speed: 1})
+		driveA, _ := sensor.Encode(encoders.GeoPoint{Latitude: 37.7749, Longitude: -122.4194, Speed: 25})
+		driveB, _ := sensor.Encode(encoders.GeoPoint{Latitude: 37.7749, Longitude: -122.4164, Speed: 25})
+
+		assert.Less(t, walkA.Similarity(walkB), 0.1, "At walking speed distant fixes should not overlap")
+		assert.Greater(t, driveA.Similarity(driveB), 0.2, "At driving speed the same fixes should overlap")
+	})
+
+	t.Run("Largest neighbourhood encodes within 1ms", func(t *testing.T) {
+		// Pinning both radii to the cap hashes every cell of the widest neighbourhood
+		config := sensors.NewSensorConfig()
+		config.SetParam("min_radius", 128)
+		config.SetParam("max_radius", 128)
+		sensor := newPipelineSensor(t, "geospatial", config)
+
+		point := encoders.GeoPoint{Latitude: 37.7749, Longitude: -122.4194, Speed: 30}
+		tests.NewSubMillisecondBenchmark().Run(t, "GeospatialEncode_Radius128", func() {
+			sdr, err := sensor.Encode(point)
+			require.NoError(t, err)
+			require.Len(t, sdr.ActiveBits(), config.CalculateActiveBitsCount())
+		})
+	})
+
+	t.Run("Invalid configuration is rejected", func(t *testing.T) {
+		invalid := []map[string]interface{}{
+			{"scale": 0.0},
+			{"timestep": -1.0},
+			{"min_radius": 1},
+			{"max_radius": 129},
+			{"min_radius": 129},
+		}
+
+		for _, params := range invalid {
+			config := sensors.NewSensorConfig()
+			for key, value := range params {
+				config.SetParam(key, value)
+			}
+
+			sensor := encoders.NewGeospatialSensor()
+			assert.Error(t, sensor.Configure(*config), "Params %v should be rejected", params)
+		}
+	})
+
+	t.Run("Invalid inputs trigger silent failure", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "geospatial", config)
+
+		for _, input := range []interface{}{
+			encoders.GeoPoint{Latitude: 89, Longitude: 0},
+			encoders.GeoPoint{Latitude: 0, Longitude: 200},
+			encoders.GeoPoint{Latitude: 0, Longitude: 0, Speed: -5},
+			[]float64{1},
+			map[string]interface{}{"latitude": 1.0},
+			"somewhere",
+		} {
+			sdr, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, sdr.ActiveBits(), "Input %v should produce an empty SDR", input)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGridCellPipeline validates grid cell encoding of 2D and 3D positions
+func TestGridCellPipeline(t *testing.T) {
+	newSensor := func(t *testing.T, params map[string]interface{}) sensors.SensorInterface {
+		config := sensors.NewSensorConfig()
+		for key, value := range params {
+			config.SetParam(key, value)
+		}
+		return newPipelineSensor(t, "gridcell", config)
+	}
+
+	t.Run("Nearby positions overlap, distant ones do not", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		base, err := sensor.Encode([]float64{10, 20})
+		require.NoError(t, err)
+		assert.Len(t, base.ActiveBits(), 40)
+
+		near, _ := sensor.Encode([]float64{10.05, 20.05})
+		far, _ := sensor.Encode([]float64{35, -12})
+
+		assert.Greater(t, base.Similarity(near), 0.5)
+		assert.Less(t, base.Similarity(far), 0.25)
+	})
+
+	t.Run("Positions are unique beyond any module period", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+		periods := sensor.Metadata().Capabilities["periods"].([]float64)
+		largest := periods[len(periods)-1]
+
+		base, _ := sensor.Encode([]float64{0, 0})
+		for _, step := range []float64{1, 2, 5} {
+			shifted, _ := sensor.Encode([]float64{step * largest, 0})
+			assert.Less(t, base.Similarity(shifted), 0.5, "Shift by %g periods should not repeat the encoding", step)
+		}
+	})
+
+	t.Run("Three dimensional positions", func(t *testing.T) {
+		sensor := newSensor(t, map[string]interface{}{"dimensions": 3})
+
+		base, err := sensor.Encode([]float64{1, 2, 3})
+		require.NoError(t, err)
+		assert.Len(t, base.ActiveBits(), 40)
+
+		near, _ := sensor.Encode([]float32{1.02, 2.02, 3.02})
+		far, _ := sensor.Encode([]float64{40, -8, 17})
+		assert.Greater(t, base.Similarity(near), base.Similarity(far))
+	})
+
+	t.Run("Same seed gives the same encoding", func(t *testing.T) {
+		first, _ := newSensor(t, map[string]interface{}{"seed": 7}).Encode([]float64{3, 4})
+		second, _ := newSensor(t, map[string]interface{}{"seed": 7}).Clone().Encode([]float64{3, 4})
+		other, _ := newSensor(t, map[string]interface{}{"seed": 8}).Encode([]float64{3, 4})
+
+		assert.Equal(t, first.ActiveBits(), second.ActiveBits())
+		assert.NotEqual(t, first.ActiveBits(), other.ActiveBits())
+	})
+
+	t.Run("Invalid configuration is rejected", func(t *testing.T) {
+		invalid := []map[string]interface{}{
+			{"dimensions": 4},
+			{"modules": 0},
+			{"modules": 41},
+			{"scale": 0.0},
+			{"scale_ratio": 1.0},
+		}
+
+		for _, params := range invalid {
+			config := sensors.NewSensorConfig()
+			for key, value := range params {
+				config.SetParam(key, value)
+			}
+			assert.Error(t, encoders.NewGridCellSensor().Configure(*config), "Params %v should be rejected", params)
+		}
+	})
+
+	t.Run("Invalid inputs trigger silent failure", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		for _, input := range []interface{}{[]float64{1}, []float64{1, 2, 3}, "here"} {
+			sdr, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, sdr.ActiveBits(), "Input %v should produce an empty SDR", input)
+		}
+	})
+}
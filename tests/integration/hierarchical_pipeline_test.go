@@ -0,0 +1,98 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHierarchicalPipeline validates taxonomy-aware categorical encoding
+func TestHierarchicalPipeline(t *testing.T) {
+	newSensor := func(t *testing.T, params map[string]interface{}) sensors.SensorInterface {
+		config := sensors.NewSensorConfig()
+		for key, value := range params {
+			config.SetParam(key, value)
+		}
+		return newPipelineSensor(t, "hierarchical", config)
+	}
+
+	t.Run("Similarity follows taxonomy distance", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		room, err := sensor.Encode("ams/b1/f2/r201")
+		require.NoError(t, err)
+		assert.Len(t, room.ActiveBits(), 40)
+
+		sameFloor, _ := sensor.Encode("ams/b1/f2/r202")
+		sameBuilding, _ := sensor.Encode("ams/b1/f3/r301")
+		sameSite, _ := sensor.Encode("ams/b2/f2/r201")
+		otherSite, _ := sensor.Encode("rtm/b1/f2/r201")
+
+		assert.InDelta(t, 0.75, room.Similarity(sameFloor), 0.06)
+		assert.InDelta(t, 0.50, room.Similarity(sameBuilding), 0.06)
+		assert.InDelta(t, 0.25, room.Similarity(sameSite), 0.06)
+		assert.Less(t, room.Similarity(otherSite), 0.1)
+	})
+
+	t.Run("Level weights set the share per level", func(t *testing.T) {
+		sensor := newSensor(t, map[string]interface{}{"level_weights": []interface{}{3.0, 1.0}})
+		assert.Equal(t, []int{30, 10}, sensor.Metadata().Capabilities["level_bits"])
+
+		a, _ := sensor.Encode("animal/cat")
+		b, _ := sensor.Encode("animal/dog")
+		assert.InDelta(t, 0.75, a.Similarity(b), 0.06)
+	})
+
+	t.Run("Ancestors resemble their descendants", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		building, _ := sensor.Encode("ams/b1")
+		room, _ := sensor.Encode("ams/b1/f2/r201")
+		assert.InDelta(t, 0.5, building.Similarity(room), 0.06)
+
+		fromSegments, _ := sensor.Encode([]string{"ams", "b1"})
+		padded, _ := sensor.Encode(" ams / b1 /")
+		assert.Equal(t, building.ActiveBits(), fromSegments.ActiveBits())
+		assert.Equal(t, building.ActiveBits(), padded.ActiveBits())
+	})
+
+	t.Run("Custom separator", func(t *testing.T) {
+		sensor := newSensor(t, map[string]interface{}{"separator": ".", "depth": 3})
+
+		a, _ := sensor.Encode("com.example.api")
+		b, _ := sensor.Encode("com.example.web")
+		assert.InDelta(t, 0.67, a.Similarity(b), 0.06)
+	})
+
+	t.Run("Invalid configuration is rejected", func(t *testing.T) {
+		invalid := []map[string]interface{}{
+			{"separator": ""},
+			{"depth": 0},
+			{"level_weights": []interface{}{}},
+			{"level_weights": []interface{}{1.0, -1.0}},
+			{"level_weights": []interface{}{100.0, 0.1}},
+			{"level_weights": "equal"},
+		}
+
+		for _, params := range invalid {
+			config := sensors.NewSensorConfig()
+			for key, value := range params {
+				config.SetParam(key, value)
+			}
+			assert.Error(t, encoders.NewHierarchicalSensor().Configure(*config), "Params %v should be rejected", params)
+		}
+	})
+
+	t.Run("Invalid inputs trigger silent failure", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		for _, input := range []interface{}{"", "///", []string{}, 42} {
+			sdr, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, sdr.ActiveBits(), "Input %v should produce an empty SDR", input)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package integration
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonTestFields returns a schema of a nested temperature reading and a room label
+func jsonTestFields() []encoders.JSONField {
+	temperature := sensors.NewSensorConfig()
+	temperature.SDRWidth = 1024
+	temperature.Range = &sensors.Range{Min: -20, Max: 50}
+
+	room := sensors.NewSensorConfig()
+	room.SDRWidth = 512
+	room.TargetSparsity = 0.04
+	room.SetParam("categories", []string{"kitchen", "office", "garage"})
+
+	return []encoders.JSONField{
+		{Path: "readings.0.celsius", Type: "numeric", Config: *temperature},
+		{Path: "device.room", Type: "categorical", Config: *room},
+	}
+}
+
+// TestJSONPipeline validates schema-driven encoding of raw JSON documents
+func TestJSONPipeline(t *testing.T) {
+	registry := newCompositeChildRegistry(t)
+
+	newSensor := func(t *testing.T, fields []encoders.JSONField, params map[string]interface{}) *encoders.JSONSensor {
+		sensor := encoders.NewJSONSensor(registry, fields...)
+		config := sensors.NewSensorConfig()
+		for key, value := range params {
+			config.SetParam(key, value)
+		}
+		require.NoError(t, sensor.Configure(*config), "JSON sensor configuration should succeed")
+		return sensor
+	}
+
+	t.Run("Document fields match their direct encodings", func(t *testing.T) {
+		sensor := newSensor(t, jsonTestFields(), nil)
+		composite := encoders.NewCompositeSensor(registry, compositeTestFields()...)
+		require.NoError(t, composite.Configure(*sensors.NewSensorConfig()))
+
+		document := []byte(`{"device": {"id": "d-17", "room": "office"}, "readings": [{"celsius": 21.5}]}`)
+		encoded, err := sensor.Encode(document)
+		require.NoError(t, err)
+		assert.Equal(t, 1536, encoded.Width())
+
+		expected, err := composite.Encode(map[string]interface{}{"temperature": 21.5, "room": "office"})
+		require.NoError(t, err)
+		assert.Equal(t, expected.ActiveBits(), encoded.ActiveBits())
+
+		fromString, _ := sensor.Encode(string(document))
+		fromRaw, _ := sensor.Encode(json.RawMessage(document))
+		assert.Equal(t, encoded.ActiveBits(), fromString.ActiveBits())
+		assert.Equal(t, encoded.ActiveBits(), fromRaw.ActiveBits())
+
+		assert.Equal(t, []encoders.CompositeSegment{
+			{Name: "readings.0.celsius", Type: "numeric", Offset: 0, Width: 1024},
+			{Name: "device.room", Type: "categorical", Offset: 1024, Width: 512},
+		}, sensor.Layout())
+	})
+
+	t.Run("Missing fields fail by default", func(t *testing.T) {
+		sensor := newSensor(t, jsonTestFields(), nil)
+
+		for _, document := range []string{
+			`{"readings": [{"celsius": 21.5}]}`,
+			`{"device": {"room": null}, "readings": [{"celsius": 21.5}]}`,
+			`{"device": {"room": "office"}, "readings": []}`,
+		} {
+			encoded, err := sensor.Encode(document)
+			require.NoError(t, err)
+			assert.Empty(t, encoded.ActiveBits(), "Document %s should produce an empty SDR", document)
+		}
+
+		loud := newSensor(t, jsonTestFields(), map[string]interface{}{"silent_failure": false})
+		_, err := loud.Encode(`{"readings": [{"celsius": 21.5}]}`)
+		assert.Error(t, err)
+	})
+
+	t.Run("Empty policy leaves the segment blank", func(t *testing.T) {
+		sensor := newSensor(t, jsonTestFields(), map[string]interface{}{"missing": "empty"})
+
+		encoded, err := sensor.Encode(`{"readings": [{"celsius": 21.5}]}`)
+		require.NoError(t, err)
+		require.NotEmpty(t, encoded.ActiveBits())
+		for _, bit := range encoded.ActiveBits() {
+			assert.Less(t, bit, 1024, "Missing room segment should stay empty")
+		}
+	})
+
+	t.Run("Default policy encodes the default value", func(t *testing.T) {
+		fields := jsonTestFields()
+		fields[1].Missing = "default"
+		fields[1].Default = "kitchen"
+		sensor := newSensor(t, fields, nil)
+
+		defaulted, err := sensor.Encode(`{"readings": [{"celsius": 18}]}`)
+		require.NoError(t, err)
+		explicit, _ := sensor.Encode(`{"device": {"room": "kitchen"}, "readings": [{"celsius": 18}]}`)
+		assert.Equal(t, explicit.ActiveBits(), defaulted.ActiveBits())
+
+		// Other fields keep the sensor-wide policy
+		missingTemperature, _ := sensor.Encode(`{"device": {"room": "kitchen"}}`)
+		assert.Empty(t, missingTemperature.ActiveBits())
+	})
+
+	t.Run("Documents over 1MB trigger silent failure", func(t *testing.T) {
+		sensor := newSensor(t, jsonTestFields(), nil)
+
+		padding := strings.Repeat("x", 1024*1024)
+		document := []byte(`{"device": {"room": "office"}, "readings": [{"celsius": 21.5}], "padding": "` + padding + `"}`)
+
+		encoded, err := sensor.Encode(document)
+		require.NoError(t, err)
+		assert.Empty(t, encoded.ActiveBits())
+	})
+
+	t.Run("Invalid input triggers silent failure", func(t *testing.T) {
+		sensor := newSensor(t, jsonTestFields(), nil)
+
+		for _, input := range []interface{}{`{"device": `, `[1, 2]`, 42, nil} {
+			encoded, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, encoded.ActiveBits(), "Input %v should produce an empty SDR", input)
+		}
+	})
+
+	t.Run("Invalid configuration is rejected", func(t *testing.T) {
+		missingDefault := jsonTestFields()
+		missingDefault[0].Missing = "default"
+
+		badPolicy := jsonTestFields()
+		badPolicy[0].Missing = "ignore"
+
+		emptySegment := jsonTestFields()
+		emptySegment[0].Path = "readings..celsius"
+
+		for _, fields := range [][]encoders.JSONField{missingDefault, badPolicy, emptySegment} {
+			assert.Error(t, encoders.NewJSONSensor(registry, fields...).Configure(*sensors.NewSensorConfig()))
+		}
+
+		config := sensors.NewSensorConfig()
+		config.SetParam("missing", "skip")
+		assert.Error(t, encoders.NewJSONSensor(registry, jsonTestFields()...).Configure(*config))
+	})
+}
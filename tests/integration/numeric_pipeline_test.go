@@ -2,201 +2,197 @@ package integration
 
 import (
 	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestNumericPipeline validates numeric data encoding pipeline from quickstart.md
 func TestNumericPipeline(t *testing.T) {
 	t.Run("Basic numeric encoding pipeline", func(t *testing.T) {
-		// This will fail until numeric pipeline is implemented
-		t.Skip("Numeric pipeline not implemented yet - this test must fail first")
-
-		// Future implementation test based on quickstart.md example:
-		// registry := sensors.NewRegistry()
-		// registry.Register("numeric", encoders.NewNumericSensor)
-		//
-		// sensor, err := registry.Create("numeric")
-		// require.NoError(t, err)
-		//
-		// // Configure numeric sensor per quickstart example
-		// config := sensors.NewConfig()
-		// config.SetParam("sdr_width", 2048)
-		// config.SetParam("target_sparsity", 0.02)  // 2% sparsity
-		// config.SetParam("min_value", 0.0)
-		// config.SetParam("max_value", 100.0)
-		// config.SetParam("resolution", 0.1)
-		//
-		// err = sensor.Configure(config)
-		// require.NoError(t, err, "Numeric sensor configuration should succeed")
-		//
-		// // Test encoding various numeric values
-		// testValues := []float64{0.0, 25.5, 50.0, 75.3, 100.0}
-		// var sdrs []sensors.SDR
-		//
-		// for _, value := range testValues {
-		//     sdr, err := sensor.Encode(value)
-		//     require.NoError(t, err, "Encoding %.1f should succeed", value)
-		//
-		//     // Validate SDR properties
-		//     assert.Equal(t, 2048, sdr.Width(), "SDR width should match configuration")
-		//     assert.InDelta(t, 0.02, sdr.Sparsity(), 0.005, "Sparsity should be ~2%")
-		//     assert.Len(t, sdr.ActiveBits(), int(2048*0.02), "Active bits should match sparsity")
-		//
-		//     sdrs = append(sdrs, sdr)
-		// }
-		//
-		// // Verify consistency: same input produces same SDR
-		// sdr1, _ := sensor.Encode(42.5)
-		// sdr2, _ := sensor.Encode(42.5)
-		// assert.Equal(t, sdr1.ActiveBits(), sdr2.ActiveBits(),
-		//             "Same input should produce identical SDR")
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "numeric", config)
+
+		// Test encoding various numeric values
+		testValues := []float64{0.0, 25.5, 50.0, 75.3, 100.0}
+		for _, value := range testValues {
+			sdr, err := sensor.Encode(value)
+			require.NoError(t, err, "Encoding %.1f should succeed", value)
+
+			// Validate SDR properties
+			assert.Equal(t, 2048, sdr.Width(), "SDR width should match configuration")
+			assert.InDelta(t, 0.02, sdr.Sparsity(), 0.005, "Sparsity should be ~2%")
+			assert.Len(t, sdr.ActiveBits(), config.CalculateActiveBitsCount(), "Active bits should match sparsity")
+		}
+
+		// Verify consistency: same input produces same SDR
+		sdr1, _ := sensor.Encode(42.5)
+		sdr2, _ := sensor.Encode(42.5)
+		assert.Equal(t, sdr1.ActiveBits(), sdr2.ActiveBits(), "Same input should produce identical SDR")
 	})
 
 	t.Run("Numeric range validation", func(t *testing.T) {
-		t.Skip("Numeric pipeline not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := sensors.NewRegistry()
-		// registry.Register("numeric", encoders.NewNumericSensor)
-		// sensor, _ := registry.Create("numeric")
-		//
-		// config := sensors.NewConfig()
-		// config.SetParam("sdr_width", 1000)
-		// config.SetParam("target_sparsity", 0.02)
-		// config.SetParam("min_value", 0.0)
-		// config.SetParam("max_value", 100.0)
-		// sensor.Configure(config)
-		//
-		// // Test values within range
-		// validValues := []float64{0.0, 50.0, 100.0}
-		// for _, value := range validValues {
-		//     sdr, err := sensor.Encode(value)
-		//     require.NoError(t, err, "Valid value %.1f should encode successfully", value)
-		//     assert.Greater(t, len(sdr.ActiveBits()), 0, "Valid values should produce non-empty SDR")
-		// }
-		//
-		// // Test values outside range (should trigger silent failure)
-		// invalidValues := []float64{-10.0, 150.0}
-		// for _, value := range invalidValues {
-		//     sdr, err := sensor.Encode(value)
-		//     require.NoError(t, err, "Silent failure should not return error for %.1f", value)
-		//     assert.Equal(t, 0, len(sdr.ActiveBits()),
-		//                 "Out-of-range value %.1f should produce empty SDR", value)
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 1000
+		config.Resolution = 1.0
+		sensor := newPipelineSensor(t, "numeric", config)
+
+		// Test values within range
+		validValues := []float64{0.0, 50.0, 100.0}
+		for _, value := range validValues {
+			sdr, err := sensor.Encode(value)
+			require.NoError(t, err, "Valid value %.1f should encode successfully", value)
+			assert.Greater(t, len(sdr.ActiveBits()), 0, "Valid values should produce non-empty SDR")
+		}
+
+		// Test values outside range (should trigger silent failure)
+		invalidValues := []float64{-10.0, 150.0}
+		for _, value := range invalidValues {
+			sdr, err := sensor.Encode(value)
+			require.NoError(t, err, "Silent failure should not return error for %.1f", value)
+			assert.Equal(t, 0, len(sdr.ActiveBits()), "Out-of-range value %.1f should produce empty SDR", value)
+		}
 	})
 
-	t.Run("Numeric precision scaling", func(t *testing.T) {
-		t.Skip("Numeric pipeline not implemented yet - this test must fail first")
+	t.Run("Out-of-range handling modes", func(t *testing.T) {
+		clipConfig := sensors.NewSensorConfig()
+		clipConfig.SetParam("clip_input", true)
+		clipping := newPipelineSensor(t, "numeric", clipConfig)
+
+		clipped, err := clipping.Encode(150.0)
+		require.NoError(t, err)
+		maxSDR, err := clipping.Encode(100.0)
+		require.NoError(t, err)
+		assert.Equal(t, maxSDR.ActiveBits(), clipped.ActiveBits(), "Clipped value should encode as range maximum")
 
-		// Future implementation test:
+		strictConfig := sensors.NewSensorConfig()
+		strictConfig.SetParam("silent_failure", false)
+		strict := newPipelineSensor(t, "numeric", strictConfig)
+
+		_, err = strict.Encode(-10.0)
+		assert.Error(t, err, "Out-of-range value should fail outside silent failure mode")
+		_, err = strict.Encode("not a number")
+		assert.Error(t, err, "Non-numeric input should fail outside silent failure mode")
+	})
+
+	t.Run("Numeric precision scaling", func(t *testing.T) {
 		// Test different resolution values
-		// registry := sensors.NewRegistry()
-		// registry.Register("numeric", encoders.NewNumericSensor)
-		//
-		// resolutions := []float64{1.0, 0.1, 0.01}
-		// for _, resolution := range resolutions {
-		//     sensor, _ := registry.Create("numeric")
-		//
-		//     config := sensors.NewConfig()
-		//     config.SetParam("sdr_width", 2048)
-		//     config.SetParam("target_sparsity", 0.02)
-		//     config.SetParam("min_value", 0.0)
-		//     config.SetParam("max_value", 100.0)
-		//     config.SetParam("resolution", resolution)
-		//     sensor.Configure(config)
-		//
-		//     // Test that similar values have similar SDRs with appropriate resolution
-		//     value1 := 50.0
-		//     value2 := 50.0 + resolution/2  // Half resolution step
-		//     value3 := 50.0 + resolution*2  // Two resolution steps
-		//
-		//     sdr1, _ := sensor.Encode(value1)
-		//     sdr2, _ := sensor.Encode(value2)
-		//     sdr3, _ := sensor.Encode(value3)
-		//
-		//     // Values within same resolution bucket should have high similarity
-		//     similarity12 := sdr1.Similarity(sdr2)
-		//     similarity13 := sdr1.Similarity(sdr3)
-		//
-		//     assert.Greater(t, similarity12, 0.8,
-		//                   "Values within resolution should be very similar")
-		//     assert.Less(t, similarity13, similarity12,
-		//                 "Values farther apart should be less similar")
-		// }
+		// A 0.01 resolution needs 10001 buckets over the 0-100 range, more
+		// start positions than the default width provides
+		resolutions := []struct {
+			resolution float64
+			width      int
+		}{
+			{1.0, 2048},
+			{0.1, 2048},
+			{0.01, 16384},
+		}
+		for _, tc := range resolutions {
+			resolution := tc.resolution
+			config := sensors.NewSensorConfig()
+			config.SDRWidth = tc.width
+			config.Resolution = resolution
+			sensor := newPipelineSensor(t, "numeric", config)
+
+			// Test that similar values have similar SDRs with appropriate resolution
+			value1 := 50.0
+			value2 := 50.0 + resolution/4 // Same resolution bucket
+			value3 := 50.0 + resolution*2 // Two resolution steps
+
+			sdr1, _ := sensor.Encode(value1)
+			sdr2, _ := sensor.Encode(value2)
+			sdr3, _ := sensor.Encode(value3)
+
+			// Values within same resolution bucket should have high similarity
+			similarity12 := sdr1.Similarity(sdr2)
+			similarity13 := sdr1.Similarity(sdr3)
+
+			assert.Greater(t, similarity12, 0.8, "Values within resolution should be very similar")
+			assert.Less(t, similarity13, similarity12, "Values farther apart should be less similar")
+		}
+	})
+
+	t.Run("Resolution too fine for SDR width", func(t *testing.T) {
+		sensor := encoders.NewNumericSensor()
+
+		config := sensors.NewSensorConfig()
+		config.Resolution = 0.001
+		assert.Error(t, sensor.Configure(*config), "Configuration needing more buckets than positions should fail")
+		assert.Error(t, sensor.Validate(), "Sensor should remain unconfigured after failed configuration")
+	})
+
+	t.Run("FR-010 overlap for nearby values", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "numeric", config)
+
+		radius, ok := sensor.Metadata().Capabilities["radius"].(float64)
+		require.True(t, ok, "Metadata should report the encoder radius")
+
+		base, _ := sensor.Encode(50.0)
+		near, _ := sensor.Encode(50.0 + radius*0.2)
+		far, _ := sensor.Encode(50.0 + radius*1.5)
+
+		assert.GreaterOrEqual(t, base.Similarity(near), 0.6, "Values within 20% of radius should overlap ≥60%")
+		assert.LessOrEqual(t, base.Similarity(far), 0.1, "Values beyond radius should overlap ≤10%")
 	})
 
 	t.Run("Numeric type conversion", func(t *testing.T) {
-		t.Skip("Numeric pipeline not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Test encoding different numeric types
-		// registry := sensors.NewRegistry()
-		// registry.Register("numeric", encoders.NewNumericSensor)
-		// sensor, _ := registry.Create("numeric")
-		//
-		// config := sensors.NewConfig()
-		// config.SetParam("sdr_width", 1000)
-		// config.SetParam("target_sparsity", 0.02)
-		// config.SetParam("min_value", 0.0)
-		// config.SetParam("max_value", 100.0)
-		// sensor.Configure(config)
-		//
-		// // Test various numeric types that should all convert to the same value
-		// testInputs := []interface{}{
-		//     42.0,    // float64
-		//     42,      // int
-		//     int32(42), // int32
-		//     int64(42), // int64
-		//     float32(42.0), // float32
-		// }
-		//
-		// var sdrs []sensors.SDR
-		// for _, input := range testInputs {
-		//     sdr, err := sensor.Encode(input)
-		//     require.NoError(t, err, "Should encode numeric type %T", input)
-		//     sdrs = append(sdrs, sdr)
-		// }
-		//
-		// // All should produce the same SDR
-		// firstSDR := sdrs[0]
-		// for i, sdr := range sdrs[1:] {
-		//     assert.Equal(t, firstSDR.ActiveBits(), sdr.ActiveBits(),
-		//                 "Input type %T should produce same SDR as float64", testInputs[i+1])
-		// }
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 1000
+		config.Resolution = 1.0
+		sensor := newPipelineSensor(t, "numeric", config)
+
+		// Test various numeric types that should all convert to the same value
+		testInputs := []interface{}{
+			42.0,          // float64
+			42,            // int
+			int32(42),     // int32
+			int64(42),     // int64
+			float32(42.0), // float32
+			uint8(42),     // uint8
+		}
+
+		var sdrs []sensors.SDR
+		for _, input := range testInputs {
+			sdr, err := sensor.Encode(input)
+			require.NoError(t, err, "Should encode numeric type %T", input)
+			sdrs = append(sdrs, sdr)
+		}
+
+		// All should produce the same SDR
+		firstSDR := sdrs[0]
+		for i, sdr := range sdrs[1:] {
+			assert.Equal(t, firstSDR.ActiveBits(), sdr.ActiveBits(),
+				"Input type %T should produce same SDR as float64", testInputs[i+1])
+		}
 	})
 
 	t.Run("HTM sparsity compliance", func(t *testing.T) {
-		t.Skip("Numeric pipeline not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// Verify that all numeric encodings maintain HTM sparsity requirements
-		// registry := sensors.NewRegistry()
-		// registry.Register("numeric", encoders.NewNumericSensor)
-		// sensor, _ := registry.Create("numeric")
-		//
-		// // Test various sparsity configurations
-		// sparsityConfigs := []float64{0.02, 0.03, 0.05}
-		// for _, targetSparsity := range sparsityConfigs {
-		//     config := sensors.NewConfig()
-		//     config.SetParam("sdr_width", 2000)
-		//     config.SetParam("target_sparsity", targetSparsity)
-		//     config.SetParam("min_value", 0.0)
-		//     config.SetParam("max_value", 100.0)
-		//     sensor.Configure(config)
-		//
-		//     // Test multiple values to ensure consistent sparsity
-		//     for i := 0; i < 20; i++ {
-		//         value := float64(i * 5) // 0, 5, 10, ..., 95
-		//         sdr, err := sensor.Encode(value)
-		//         require.NoError(t, err)
-		//
-		//         actualSparsity := sdr.Sparsity()
-		//         assert.InDelta(t, targetSparsity, actualSparsity, 0.005,
-		//                       "Sparsity for value %.1f should be close to target", value)
-		//         assert.GreaterOrEqual(t, actualSparsity, 0.02,
-		//                               "Sparsity should be >= 2% for HTM compliance")
-		//         assert.LessOrEqual(t, actualSparsity, 0.05,
-		//                           "Sparsity should be <= 5% for HTM compliance")
-		//     }
-		// }
+		// Test various sparsity configurations
+		sparsityConfigs := []float64{0.02, 0.03, 0.05}
+		for _, targetSparsity := range sparsityConfigs {
+			config := sensors.NewSensorConfig()
+			config.SDRWidth = 2000
+			config.TargetSparsity = targetSparsity
+			config.Resolution = 1.0
+			sensor := newPipelineSensor(t, "numeric", config)
+
+			// Test multiple values to ensure consistent sparsity
+			for i := 0; i < 20; i++ {
+				value := float64(i * 5) // 0, 5, 10, ..., 95
+				sdr, err := sensor.Encode(value)
+				require.NoError(t, err)
+
+				actualSparsity := sdr.Sparsity()
+				assert.InDelta(t, targetSparsity, actualSparsity, 0.005,
+					"Sparsity for value %.1f should be close to target", value)
+				assert.GreaterOrEqual(t, actualSparsity, 0.02,
+					"Sparsity should be >= 2% for HTM compliance")
+				assert.LessOrEqual(t, actualSparsity, 0.05,
+					"Sparsity should be <= 5% for HTM compliance")
+			}
+		}
 	})
 }
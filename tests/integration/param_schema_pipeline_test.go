@@ -0,0 +1,144 @@
+package integration
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParamSchemaPipeline validates typed custom parameter schemas
+func TestParamSchemaPipeline(t *testing.T) {
+	registry, err := encoders.NewDefaultRegistry()
+	require.NoError(t, err)
+
+	configure := func(t *testing.T, sensorType string, params map[string]interface{}) (sensors.SensorInterface, error) {
+		sensor, err := registry.Create(sensorType)
+		require.NoError(t, err)
+
+		config := sensors.NewSensorConfig()
+		for key, value := range params {
+			config.SetParam(key, value)
+		}
+		return sensor, sensor.Configure(*config)
+	}
+
+	t.Run("JSON-decoded parameters are coerced", func(t *testing.T) {
+		var params map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(`{"ngram_size": 3, "seed": 7, "case_insensitive": false}`), &params))
+
+		_, err := configure(t, "text", params)
+		require.NoError(t, err, "Whole float64 values should be accepted for int parameters")
+
+		schema, err := registry.Parameters("text")
+		require.NoError(t, err)
+		config := sensors.NewSensorConfig()
+		for key, value := range params {
+			config.SetParam(key, value)
+		}
+		require.NoError(t, schema.Apply(config))
+		assert.Equal(t, 3, config.CustomParams["ngram_size"])
+		assert.Equal(t, 7, config.CustomParams["seed"])
+		assert.Equal(t, false, config.CustomParams["case_insensitive"])
+
+		schema, err = registry.Parameters("hierarchical")
+		require.NoError(t, err)
+		config = sensors.NewSensorConfig()
+		config.SetParam("level_weights", []interface{}{2, 1.0, 1})
+		require.NoError(t, schema.Apply(config))
+		assert.Equal(t, []float64{2, 1, 1}, config.CustomParams["level_weights"])
+
+		_, err = configure(t, "categorical", map[string]interface{}{
+			"categories": []interface{}{"red", "green"},
+			"seed":       json.Number("11"),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Wrong types and values are rejected", func(t *testing.T) {
+		cases := []struct {
+			sensorType string
+			params     map[string]interface{}
+			parameter  string
+		}{
+			{"text", map[string]interface{}{"ngram_size": 2.5}, "ngram_size"},
+			{"text", map[string]interface{}{"ngram_size": "3"}, "ngram_size"},
+			{"text", map[string]interface{}{"ngram_size": 9}, "ngram_size"},
+			{"numeric", map[string]interface{}{"clip_input": "yes"}, "clip_input"},
+			{"numeric", map[string]interface{}{"silent_failure": 1}, "silent_failure"},
+			{"spatial", map[string]interface{}{"binarization": "otsu"}, "binarization"},
+			{"audio", map[string]interface{}{"window": "hamming"}, "window"},
+			{"rdse", map[string]interface{}{"seed": -1}, "seed"},
+			{"categorical", map[string]interface{}{"categories": []interface{}{"red", 1}}, "categories"},
+			{"categorical", map[string]interface{}{"groups": []string{"red"}}, "groups"},
+			{"embedding", nil, "dimensions"},
+		}
+
+		for _, tc := range cases {
+			_, err := configure(t, tc.sensorType, tc.params)
+			require.Error(t, err, "%s should reject %v", tc.sensorType, tc.params)
+
+			var configErr *sensors.ConfigurationError
+			require.True(t, errors.As(err, &configErr), "Expected a ConfigurationError, got %T", err)
+			assert.Equal(t, tc.parameter, configErr.Parameter)
+		}
+	})
+
+	t.Run("Unknown parameters are rejected", func(t *testing.T) {
+		_, err := configure(t, "numeric", map[string]interface{}{"label": "outdoor"})
+		require.Error(t, err)
+
+		var configErr *sensors.ConfigurationError
+		require.True(t, errors.As(err, &configErr), "Expected a ConfigurationError, got %T", err)
+		assert.Equal(t, "label", configErr.Parameter)
+
+		// Reserved parameters are accepted without being declared
+		config := sensors.NewSensorConfig()
+		config.SetParam("silent_failure", false)
+		assert.NoError(t, sensors.ParamSchema{sensors.IntParam("size", "Size")}.Apply(config))
+	})
+
+	t.Run("Schemas are discoverable", func(t *testing.T) {
+		schema, err := registry.Parameters("text")
+		require.NoError(t, err)
+
+		spec, found := schema.Lookup("ngram_size")
+		require.True(t, found)
+		assert.Equal(t, sensors.ParamInt, spec.Type)
+		assert.Equal(t, 2, spec.Default)
+		require.NotNil(t, spec.Min)
+		require.NotNil(t, spec.Max)
+		assert.Equal(t, 1.0, *spec.Min)
+		assert.Equal(t, 8.0, *spec.Max)
+		assert.NotEmpty(t, spec.Description)
+
+		spec, found = schema.Lookup("seed")
+		require.True(t, found)
+		assert.Equal(t, sensors.ParamInt, spec.Type)
+
+		embedding, err := registry.Parameters("embedding")
+		require.NoError(t, err)
+		spec, _ = embedding.Lookup("dimensions")
+		assert.True(t, spec.Required)
+
+		_, err = registry.Parameters("thermometer")
+		assert.Error(t, err)
+
+		for _, builtin := range encoders.Builtins() {
+			sensor, err := registry.Create(builtin.Type)
+			require.NoError(t, err)
+
+			spec, found := sensor.Metadata().Parameters.Lookup("silent_failure")
+			assert.True(t, found, "%s should declare silent_failure", builtin.Type)
+			assert.Equal(t, sensors.ParamBool, spec.Type)
+		}
+
+		encoded, err := json.Marshal(schema)
+		require.NoError(t, err)
+		assert.Contains(t, string(encoded), `"name":"ngram_size"`)
+	})
+}
@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spacedBits returns count active bit indices spaced step apart from start
+func spacedBits(start, step, count int) []int {
+	bits := make([]int, count)
+	for i := range bits {
+		bits[i] = start + i*step
+	}
+	return bits
+}
+
+// TestPassthroughPipeline validates pass-through encoding of pre-encoded SDRs
+func TestPassthroughPipeline(t *testing.T) {
+	newSensor := func(t *testing.T, params map[string]interface{}) sensors.SensorInterface {
+		config := sensors.NewSensorConfig()
+		for key, value := range params {
+			config.SetParam(key, value)
+		}
+		return newPipelineSensor(t, "passthrough", config)
+	}
+
+	t.Run("Index lists and bitsets pass through unchanged", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+		active := spacedBits(3, 50, 40)
+
+		encoded, err := sensor.Encode(active)
+		require.NoError(t, err)
+		assert.Equal(t, 2048, encoded.Width())
+		assert.Equal(t, active, encoded.ActiveBits())
+
+		words := make([]uint64, 32)
+		packed := make([]byte, 256)
+		indices := make([]uint32, len(active))
+		for i, bit := range active {
+			words[bit/64] |= 1 << (bit % 64)
+			packed[bit/8] |= 1 << (bit % 8)
+			indices[i] = uint32(bit)
+		}
+
+		for _, input := range []interface{}{words, packed, indices} {
+			converted, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Equal(t, active, converted.ActiveBits(), "Input %T should decode to the same bits", input)
+		}
+	})
+
+	t.Run("Out of range or off-target inputs are rejected", func(t *testing.T) {
+		sensor := newSensor(t, map[string]interface{}{"silent_failure": false})
+
+		invalid := []interface{}{
+			[]int{-1, 5},
+			[]int{2048},
+			spacedBits(0, 10, 5),    // 5 bits, below 1% sparsity
+			spacedBits(0, 5, 300),   // 300 bits, above 10% sparsity
+			make([]uint64, 31),      // Wrong bitset length
+			[]string{"1", "2", "3"}, // Unsupported type
+		}
+		for _, input := range invalid {
+			_, err := sensor.Encode(input)
+			assert.Error(t, err, "Input %v should be rejected", input)
+		}
+
+		silent := newSensor(t, nil)
+		encoded, err := silent.Encode([]int{2048})
+		require.NoError(t, err)
+		assert.Empty(t, encoded.ActiveBits())
+	})
+
+	t.Run("Subsampling and padding hit the target sparsity", func(t *testing.T) {
+		sensor := newSensor(t, map[string]interface{}{"subsample": true, "pad": true})
+
+		dense := spacedBits(0, 5, 300)
+		reduced, err := sensor.Encode(dense)
+		require.NoError(t, err)
+		assert.Len(t, reduced.ActiveBits(), 40)
+		assert.Subset(t, dense, reduced.ActiveBits())
+
+		sparse := spacedBits(7, 200, 10)
+		padded, err := sensor.Encode(sparse)
+		require.NoError(t, err)
+		assert.Len(t, padded.ActiveBits(), 40)
+		assert.Subset(t, padded.ActiveBits(), sparse)
+
+		// Inputs that differ in one bit keep most of their adjustments
+		shifted := append(spacedBits(7, 200, 9), 1999)
+		paddedShifted, _ := sensor.Encode(shifted)
+		assert.Greater(t, padded.Similarity(paddedShifted), 0.7)
+
+		denseShifted := append(spacedBits(0, 5, 299), 2047)
+		reducedShifted, _ := sensor.Encode(denseShifted)
+		assert.Greater(t, reduced.Similarity(reducedShifted), 0.9)
+	})
+
+	t.Run("Composes with built-in encoders", func(t *testing.T) {
+		upstream := sensors.NewSensorConfig()
+		upstream.SDRWidth = 1024
+
+		level := sensors.NewSensorConfig()
+		level.SDRWidth = 512
+		level.Range = &sensors.Range{Min: 0, Max: 100}
+		level.Resolution = 1
+
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+
+		composite := encoders.NewCompositeSensor(registry,
+			encoders.CompositeField{Name: "features", Type: "passthrough", Config: *upstream},
+			encoders.CompositeField{Name: "level", Type: "numeric", Config: *level},
+		)
+		require.NoError(t, composite.Configure(*sensors.NewSensorConfig()))
+
+		features := spacedBits(1, 50, 20)
+		encoded, err := composite.Encode(map[string]interface{}{"features": features, "level": 42.0})
+		require.NoError(t, err)
+		assert.Equal(t, 1536, encoded.Width())
+		assert.Equal(t, features, encoded.ActiveBits()[:20])
+	})
+}
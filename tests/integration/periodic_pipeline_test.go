@@ -0,0 +1,115 @@
+package integration
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCompassConfig returns a 0-360° periodic configuration with a 20° radius
+func newCompassConfig() *sensors.SensorConfig {
+	config := sensors.NewSensorConfig()
+	config.Range = &sensors.Range{Min: 0, Max: 360}
+	config.SetParam("radius", 20.0)
+	return config
+}
+
+// TestPeriodicPipeline validates wrap-around encoding of cyclic values
+func TestPeriodicPipeline(t *testing.T) {
+	newSensor := func(t *testing.T, config *sensors.SensorConfig) sensors.SensorInterface {
+		return newPipelineSensor(t, "periodic", config)
+	}
+
+	t.Run("Range ends are neighbours", func(t *testing.T) {
+		sensor := newSensor(t, newCompassConfig())
+
+		north, err := sensor.Encode(0.0)
+		require.NoError(t, err)
+		assert.Len(t, north.ActiveBits(), 40)
+
+		justWest, _ := sensor.Encode(355.0)
+		justEast, _ := sensor.Encode(5.0)
+		south, _ := sensor.Encode(180.0)
+
+		assert.InDelta(t, north.Similarity(justWest), north.Similarity(justEast), 0.05, "Wrap-around should be symmetric")
+		assert.Greater(t, north.Similarity(justWest), 0.6)
+		assert.Less(t, north.Similarity(south), 0.1)
+	})
+
+	t.Run("Values outside the range fold back", func(t *testing.T) {
+		sensor := newSensor(t, newCompassConfig())
+
+		base, _ := sensor.Encode(10.0)
+		turned, _ := sensor.Encode(370.0)
+		negative, _ := sensor.Encode(-350.0)
+
+		assert.Equal(t, base.ActiveBits(), turned.ActiveBits())
+		assert.Equal(t, base.ActiveBits(), negative.ActiveBits())
+	})
+
+	t.Run("Radius sets the overlap distance", func(t *testing.T) {
+		sensor := newSensor(t, newCompassConfig())
+		assert.InDelta(t, 20.0, sensor.Metadata().Capabilities["radius"], 0.5)
+
+		base, _ := sensor.Encode(90.0)
+		beyond, _ := sensor.Encode(115.0)
+		assert.Less(t, base.Similarity(beyond), 0.1, "Values further apart than the radius should barely overlap")
+	})
+
+	t.Run("Resolution without radius", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.Range = &sensors.Range{Min: 0, Max: 1}
+		config.Resolution = 0.001
+		sensor := newSensor(t, config)
+
+		start, _ := sensor.Encode(0.0)
+		end, _ := sensor.Encode(0.999)
+		assert.Greater(t, start.Similarity(end), 0.9)
+	})
+
+	t.Run("Radius accepts any numeric type", func(t *testing.T) {
+		expected := newSensor(t, newCompassConfig()).Metadata().Fingerprint
+
+		for _, radius := range []interface{}{json.Number("20"), int64(20), float32(20), 20} {
+			config := newCompassConfig()
+			config.SetParam("radius", radius)
+			sensor := newSensor(t, config)
+			assert.Equal(t, expected, sensor.Metadata().Fingerprint, "Radius %v (%T) should configure like 20.0", radius, radius)
+		}
+	})
+
+	t.Run("Invalid configuration is rejected", func(t *testing.T) {
+		tooWide := newCompassConfig()
+		tooWide.SetParam("radius", 200.0)
+		assert.Error(t, tooWide.IsValid(), "Radius above half the period should fail IsValid")
+
+		negative := newCompassConfig()
+		negative.SetParam("radius", -1.0)
+		assert.Error(t, negative.IsValid())
+
+		coarse := sensors.NewSensorConfig()
+		coarse.Range = &sensors.Range{Min: 0, Max: 360}
+		coarse.Resolution = 10
+
+		noRange := newCompassConfig()
+		noRange.Range = nil
+
+		for _, config := range []*sensors.SensorConfig{tooWide, negative, coarse, noRange} {
+			assert.Error(t, encoders.NewPeriodicSensor().Configure(*config), "Config %s should be rejected", config)
+		}
+	})
+
+	t.Run("Invalid inputs trigger silent failure", func(t *testing.T) {
+		sensor := newSensor(t, newCompassConfig())
+
+		for _, input := range []interface{}{"north", []float64{1}} {
+			sdr, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, sdr.ActiveBits(), "Input %v should produce an empty SDR", input)
+		}
+	})
+}
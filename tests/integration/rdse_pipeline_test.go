@@ -0,0 +1,62 @@
+package integration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRDSEPipeline validates random distributed scalar encoding of unbounded values
+func TestRDSEPipeline(t *testing.T) {
+	newSensor := func(t *testing.T, seed int) sensors.SensorInterface {
+		config := sensors.NewSensorConfig()
+		config.Range = nil
+		config.Resolution = 1.0
+		config.SetParam("seed", seed)
+		return newPipelineSensor(t, "rdse", config)
+	}
+
+	t.Run("Encodes values without range configuration", func(t *testing.T) {
+		sensor := newSensor(t, 42)
+
+		for _, value := range []float64{-1e9, -3.5, 0, 17, 1e12} {
+			sdr, err := sensor.Encode(value)
+			require.NoError(t, err)
+			assert.Equal(t, 2048, sdr.Width())
+			assert.Len(t, sdr.ActiveBits(), 40, "Every value should activate exactly the configured bit count")
+		}
+	})
+
+	t.Run("Neighbouring values overlap", func(t *testing.T) {
+		sensor := newSensor(t, 42)
+
+		base, _ := sensor.Encode(1000.0)
+		near, _ := sensor.Encode(1004.0)
+		far, _ := sensor.Encode(2000.0)
+
+		assert.GreaterOrEqual(t, base.Similarity(near), 0.8, "Values a few buckets apart should share most bits")
+		assert.LessOrEqual(t, base.Similarity(far), 0.1, "Distant values should share almost no bits")
+	})
+
+	t.Run("Deterministic seeding", func(t *testing.T) {
+		first, _ := newSensor(t, 7).Encode(123.4)
+		second, _ := newSensor(t, 7).Encode(123.4)
+		other, _ := newSensor(t, 8).Encode(123.4)
+
+		assert.Equal(t, first.ActiveBits(), second.ActiveBits(), "Same seed should produce identical SDRs")
+		assert.NotEqual(t, first.ActiveBits(), other.ActiveBits(), "Different seeds should produce different SDRs")
+	})
+
+	t.Run("Non-finite values trigger silent failure", func(t *testing.T) {
+		sensor := newSensor(t, 42)
+
+		for _, value := range []float64{math.NaN(), math.Inf(1)} {
+			sdr, err := sensor.Encode(value)
+			require.NoError(t, err)
+			assert.Empty(t, sdr.ActiveBits())
+		}
+	})
+}
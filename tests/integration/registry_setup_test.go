@@ -1,173 +1,179 @@
 package integration
 
 import (
+	"fmt"
+	"sync"
 	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestRegistrySetup validates sensor registry setup and registration scenarios
 func TestRegistrySetup(t *testing.T) {
 	t.Run("Registry initialization and built-in registration", func(t *testing.T) {
-		// This will fail until Registry is implemented
-		t.Skip("Registry not implemented yet - this test must fail first")
-
-		// Future implementation test based on quickstart.md:
-		// registry := sensors.NewRegistry()
-		//
-		// // Register built-in sensor types
-		// registry.Register("numeric", encoders.NewNumericSensor)
-		// registry.Register("categorical", encoders.NewCategoricalSensor)
-		// registry.Register("text", encoders.NewTextSensor)
-		// registry.Register("spatial", encoders.NewSpatialSensor)
-		//
-		// // Verify all expected types are registered
-		// expectedTypes := []string{"numeric", "categorical", "text", "spatial"}
-		// registeredTypes := registry.List()
-		//
-		// assert.Len(t, registeredTypes, 4, "Should have 4 built-in sensor types")
-		// for _, expectedType := range expectedTypes {
-		//     assert.Contains(t, registeredTypes, expectedType,
-		//                     "Should contain built-in type: %s", expectedType)
-		//     assert.True(t, registry.IsRegistered(expectedType),
-		//                 "Should report type as registered: %s", expectedType)
-		// }
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+
+		registeredTypes := registry.List()
+		assert.Len(t, registeredTypes, len(encoders.Builtins()), "Every built-in sensor type should be registered")
+		for _, builtin := range encoders.Builtins() {
+			assert.Contains(t, registeredTypes, builtin.Type,
+				"Should contain built-in type: %s", builtin.Type)
+			assert.True(t, registry.IsRegistered(builtin.Type),
+				"Should report type as registered: %s", builtin.Type)
+
+			version, tagged := registry.Version(builtin.Type)
+			assert.True(t, tagged, "Built-in type %s should carry a version tag", builtin.Type)
+			assert.Equal(t, encoders.BuiltinVersion, version)
+		}
+
+		info := registry.GetInfo()
+		assert.True(t, info.Built_insLoaded, "Registry info should report built-ins as loaded")
+		assert.Equal(t, len(registeredTypes), info.Count)
+
+		assert.Error(t, encoders.RegisterBuiltins(registry), "Registering built-ins twice should fail")
 	})
 
 	t.Run("Sensor creation from registry", func(t *testing.T) {
-		t.Skip("Registry not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := sensors.NewRegistry()
-		// registry.Register("numeric", encoders.NewNumericSensor)
-		//
-		// // Create sensor instance
-		// sensor, err := registry.Create("numeric")
-		// require.NoError(t, err, "Should create numeric sensor successfully")
-		// require.NotNil(t, sensor, "Created sensor should not be nil")
-		//
-		// // Verify sensor metadata
-		// metadata := sensor.Metadata()
-		// assert.Equal(t, "numeric", metadata.Type, "Sensor should report correct type")
-		// assert.Greater(t, metadata.MaxInputSize, 0, "Should have positive max input size")
-		//
-		// // Test unknown sensor type
-		// unknownSensor, err := registry.Create("unknown")
-		// assert.Error(t, err, "Creating unknown sensor should fail")
-		// assert.Nil(t, unknownSensor, "Unknown sensor should return nil")
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+
+		// Create sensor instance
+		sensor, err := registry.Create("numeric")
+		require.NoError(t, err, "Should create numeric sensor successfully")
+		require.NotNil(t, sensor, "Created sensor should not be nil")
+
+		// Verify sensor metadata
+		metadata := sensor.Metadata()
+		assert.Equal(t, "numeric", metadata.Type, "Sensor should report correct type")
+		assert.Equal(t, encoders.BuiltinVersion, metadata.Version)
+		assert.Greater(t, metadata.MaxInputSize, 0, "Should have positive max input size")
+
+		// Test unknown sensor type
+		unknownSensor, err := registry.Create("unknown")
+		assert.Error(t, err, "Creating unknown sensor should fail")
+		assert.Nil(t, unknownSensor, "Unknown sensor should return nil")
 	})
 
 	t.Run("Multiple sensor instances independence", func(t *testing.T) {
-		t.Skip("Registry not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := sensors.NewRegistry()
-		// registry.Register("numeric", encoders.NewNumericSensor)
-		//
-		// // Create multiple instances
-		// sensor1, err1 := registry.Create("numeric")
-		// sensor2, err2 := registry.Create("numeric")
-		//
-		// require.NoError(t, err1)
-		// require.NoError(t, err2)
-		// assert.NotSame(t, sensor1, sensor2, "Each Create call should return new instance")
-		//
-		// // Configure them differently
-		// config1 := sensors.NewConfig()
-		// config1.SetParam("sdr_width", 1000)
-		// config1.SetParam("target_sparsity", 0.02)
-		//
-		// config2 := sensors.NewConfig()
-		// config2.SetParam("sdr_width", 2000)
-		// config2.SetParam("target_sparsity", 0.03)
-		//
-		// err1 = sensor1.Configure(config1)
-		// err2 = sensor2.Configure(config2)
-		//
-		// require.NoError(t, err1)
-		// require.NoError(t, err2)
-		//
-		// // Verify independent configurations
-		// meta1 := sensor1.Metadata()
-		// meta2 := sensor2.Metadata()
-		// assert.NotEqual(t, meta1.SDRWidth, meta2.SDRWidth, "Sensors should have independent configs")
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+
+		// Create multiple instances
+		sensor1, err1 := registry.Create("numeric")
+		sensor2, err2 := registry.Create("numeric")
+
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.NotSame(t, sensor1, sensor2, "Each Create call should return new instance")
+
+		// Configure them differently
+		config1 := sensors.NewSensorConfig()
+		config1.SDRWidth = 2048
+		config1.TargetSparsity = 0.02
+
+		config2 := sensors.NewSensorConfig()
+		config2.SDRWidth = 4096
+		config2.TargetSparsity = 0.03
+
+		require.NoError(t, sensor1.Configure(*config1))
+		require.NoError(t, sensor2.Configure(*config2))
+
+		// Verify independent configurations
+		meta1 := sensor1.Metadata()
+		meta2 := sensor2.Metadata()
+		assert.Equal(t, 2048, meta1.SDRWidth)
+		assert.Equal(t, 4096, meta2.SDRWidth)
+		assert.NotEqual(t, meta1.Fingerprint, meta2.Fingerprint, "Sensors should have independent configs")
 	})
 
 	t.Run("Registry thread safety for read operations", func(t *testing.T) {
-		t.Skip("Registry not implemented yet - this test must fail first")
-
-		// Future implementation test:
 		// Note: HTM package is single-threaded, but registry should be safe for concurrent reads
-		// registry := sensors.NewRegistry()
-		// registry.Register("numeric", encoders.NewNumericSensor)
-		// registry.Register("categorical", encoders.NewCategoricalSensor)
-		//
-		// var wg sync.WaitGroup
-		// errors := make(chan error, 20)
-		//
-		// // Multiple concurrent read operations
-		// for i := 0; i < 10; i++ {
-		//     wg.Add(2)
-		//     go func() {
-		//         defer wg.Done()
-		//         types := registry.List()
-		//         if len(types) != 2 {
-		//             errors <- fmt.Errorf("expected 2 types, got %d", len(types))
-		//         }
-		//     }()
-		//     go func() {
-		//         defer wg.Done()
-		//         if !registry.IsRegistered("numeric") {
-		//             errors <- fmt.Errorf("numeric should be registered")
-		//         }
-		//     }()
-		// }
-		//
-		// wg.Wait()
-		// close(errors)
-		//
-		// for err := range errors {
-		//     t.Error("Concurrent read error:", err)
-		// }
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+		expected := len(encoders.Builtins())
+
+		var wg sync.WaitGroup
+		errors := make(chan error, 30)
+
+		// Multiple concurrent read operations
+		for i := 0; i < 10; i++ {
+			wg.Add(3)
+			go func() {
+				defer wg.Done()
+				types := registry.List()
+				if len(types) != expected {
+					errors <- fmt.Errorf("expected %d types, got %d", expected, len(types))
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				if !registry.IsRegistered("numeric") {
+					errors <- fmt.Errorf("numeric should be registered")
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				if _, err := registry.Create("categorical"); err != nil {
+					errors <- err
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(errors)
+
+		for err := range errors {
+			t.Error("Concurrent read error:", err)
+		}
 	})
 
 	t.Run("Custom sensor registration", func(t *testing.T) {
-		t.Skip("Registry not implemented yet - this test must fail first")
-
-		// Future implementation test:
-		// registry := sensors.NewRegistry()
-		//
-		// // Define custom sensor factory
-		// customFactory := func() sensors.SensorInterface {
-		//     return &CustomTestSensor{name: "custom"}
-		// }
-		//
-		// // Register custom sensor
-		// err := registry.Register("custom", customFactory)
-		// require.NoError(t, err, "Custom sensor registration should succeed")
-		//
-		// // Verify registration
-		// assert.True(t, registry.IsRegistered("custom"), "Custom sensor should be registered")
-		// assert.Contains(t, registry.List(), "custom", "Custom sensor should appear in list")
-		//
-		// // Create custom sensor instance
-		// sensor, err := registry.Create("custom")
-		// require.NoError(t, err, "Custom sensor creation should succeed")
-		// require.NotNil(t, sensor, "Custom sensor should not be nil")
-		//
-		// // Verify it's our custom type
-		// customSensor, ok := sensor.(*CustomTestSensor)
-		// assert.True(t, ok, "Should be able to cast to custom type")
-		// assert.Equal(t, "custom", customSensor.name, "Custom sensor should have expected properties")
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+
+		// Define custom sensor factory
+		customFactory := func() sensors.SensorInterface {
+			return &CustomTestSensor{SensorInterface: encoders.NewNumericSensor(), name: "custom"}
+		}
+
+		// Register custom sensor
+		err = registry.Register("custom", customFactory)
+		require.NoError(t, err, "Custom sensor registration should succeed")
+		assert.Error(t, registry.Register("numeric", customFactory), "Built-in types should not be replaced")
+
+		// Verify registration
+		assert.True(t, registry.IsRegistered("custom"), "Custom sensor should be registered")
+		assert.Contains(t, registry.List(), "custom", "Custom sensor should appear in list")
+
+		// Create custom sensor instance
+		sensor, err := registry.Create("custom")
+		require.NoError(t, err, "Custom sensor creation should succeed")
+		require.NotNil(t, sensor, "Custom sensor should not be nil")
+
+		// Verify it's our custom type
+		customSensor, ok := sensor.(*CustomTestSensor)
+		assert.True(t, ok, "Should be able to cast to custom type")
+		assert.Equal(t, "custom", customSensor.name, "Custom sensor should have expected properties")
+
+		require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+		encoded, err := sensor.Encode(42.0)
+		require.NoError(t, err)
+		assert.NotEmpty(t, encoded.ActiveBits())
 	})
 }
 
-// CustomTestSensor will be implemented for testing custom sensor registration
-// type CustomTestSensor struct {
-//     name string
-//     config sensors.SensorConfig
-// }
-//
-// func (c *CustomTestSensor) Encode(input interface{}) (sensors.SDR, error) { ... }
-// func (c *CustomTestSensor) Configure(config sensors.SensorConfig) error { ... }
-// func (c *CustomTestSensor) Validate() error { ... }
-// func (c *CustomTestSensor) Metadata() sensors.SensorMetadata { ... }
-// func (c *CustomTestSensor) Clone() sensors.SensorInterface { ... }
+// CustomTestSensor is a user-defined sensor type that delegates encoding to
+// a wrapped sensor
+type CustomTestSensor struct {
+	sensors.SensorInterface
+	name string
+}
+
+// Clone creates a new custom sensor around a clone of the wrapped sensor
+func (c *CustomTestSensor) Clone() sensors.SensorInterface {
+	return &CustomTestSensor{SensorInterface: c.SensorInterface.Clone(), name: c.name}
+}
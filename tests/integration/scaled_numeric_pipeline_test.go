@@ -0,0 +1,181 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogNumericPipeline validates logarithmic encoding of wide-range values
+func TestLogNumericPipeline(t *testing.T) {
+	newSensor := func(t *testing.T, params map[string]interface{}) sensors.SensorInterface {
+		config := sensors.NewSensorConfig()
+		config.Range = &sensors.Range{Min: 0.1, Max: 100000}
+		config.Resolution = 0.01
+		for key, value := range params {
+			config.SetParam(key, value)
+		}
+		return newPipelineSensor(t, "log", config)
+	}
+
+	t.Run("Equal ratios give equal similarity", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		ms1, _ := sensor.Encode(1.0)
+		ms1p1, _ := sensor.Encode(1.1)
+		ms100, _ := sensor.Encode(100.0)
+		ms110, _ := sensor.Encode(110.0)
+
+		assert.InDelta(t, ms1.Similarity(ms1p1), ms100.Similarity(ms110), 0.05)
+		assert.Greater(t, ms1.Similarity(ms1p1), 0.5)
+		assert.Zero(t, ms1.Similarity(ms100))
+	})
+
+	t.Run("Decoding returns input units", func(t *testing.T) {
+		sensor := newSensor(t, nil)
+
+		encoded, err := sensor.Encode(250.0)
+		require.NoError(t, err)
+
+		candidates, err := sensor.(sensors.Decoder).Decode(encoded, 1)
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.InEpsilon(t, 250.0, candidates[0].Value, 0.03)
+	})
+
+	t.Run("Out-of-range values fail or clip", func(t *testing.T) {
+		silent := newSensor(t, nil)
+		for _, input := range []interface{}{0.0, -5.0, 1e6} {
+			sdr, err := silent.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, sdr.ActiveBits(), "Input %v should produce an empty SDR", input)
+		}
+
+		clipping := newSensor(t, map[string]interface{}{"clip_input": true})
+		low, _ := clipping.Encode(0.01)
+		minimum, _ := clipping.Encode(0.1)
+		assert.Equal(t, minimum.ActiveBits(), low.ActiveBits())
+	})
+
+	t.Run("Non-positive range is rejected", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		assert.Error(t, encoders.NewLogNumericSensor().Configure(*config), "Default range starts at 0")
+
+		config.Range = nil
+		assert.Error(t, encoders.NewLogNumericSensor().Configure(*config))
+	})
+}
+
+// TestAdaptiveNumericPipeline validates range learning of the adaptive encoder
+func TestAdaptiveNumericPipeline(t *testing.T) {
+	newSensor := func(t *testing.T, params map[string]interface{}) *encoders.AdaptiveNumericSensor {
+		config := sensors.NewSensorConfig()
+		for key, value := range params {
+			config.SetParam(key, value)
+		}
+		return newPipelineSensor(t, "adaptive", config).(*encoders.AdaptiveNumericSensor)
+	}
+
+	t.Run("Warm-up learns the exact range", func(t *testing.T) {
+		sensor := newSensor(t, map[string]interface{}{"warmup": 5})
+
+		_, learned := sensor.LearnedRange()
+		assert.False(t, learned)
+
+		for _, value := range []float64{10, 30, 20, 15, 25} {
+			_, err := sensor.Encode(value)
+			require.NoError(t, err)
+		}
+
+		learnedRange, learned := sensor.LearnedRange()
+		assert.True(t, learned)
+		assert.Equal(t, sensors.Range{Min: 10, Max: 30}, learnedRange)
+
+		low, _ := sensor.Encode(10.0)
+		high, _ := sensor.Encode(30.0)
+		assert.Zero(t, low.Overlap(high), "Range extremes should not overlap")
+		assert.Len(t, low.ActiveBits(), 40)
+	})
+
+	t.Run("Expand policy widens the range with margin", func(t *testing.T) {
+		sensor := newSensor(t, map[string]interface{}{"warmup": 2, "margin": 0.5})
+		_, _ = sensor.Encode(0.0)
+		_, _ = sensor.Encode(10.0)
+		_, _ = sensor.Encode(20.0)
+
+		learnedRange, _ := sensor.LearnedRange()
+		assert.Equal(t, sensors.Range{Min: 0, Max: 30}, learnedRange)
+		assert.Equal(t, 1, sensor.Metadata().Capabilities["rebucketed"])
+	})
+
+	t.Run("Clip policy keeps the learned range", func(t *testing.T) {
+		sensor := newSensor(t, map[string]interface{}{"warmup": 2, "rebucket": "clip"})
+		_, _ = sensor.Encode(0.0)
+		top, _ := sensor.Encode(10.0)
+		beyond, _ := sensor.Encode(50.0)
+
+		learnedRange, _ := sensor.LearnedRange()
+		assert.Equal(t, sensors.Range{Min: 0, Max: 10}, learnedRange)
+		assert.Equal(t, top.ActiveBits(), beyond.ActiveBits())
+	})
+
+	t.Run("Configured range seeds the learned range", func(t *testing.T) {
+		sensor := newSensor(t, map[string]interface{}{"use_range": true, "rebucket": "clip", "warmup": 0})
+
+		learnedRange, learned := sensor.LearnedRange()
+		assert.True(t, learned)
+		assert.Equal(t, sensors.Range{Min: 0, Max: 100}, learnedRange)
+	})
+
+	t.Run("Clones keep their own range", func(t *testing.T) {
+		sensor := newSensor(t, map[string]interface{}{"warmup": 10})
+		_, _ = sensor.Encode(5.0)
+		clone := sensor.Clone().(*encoders.AdaptiveNumericSensor)
+		_, _ = clone.Encode(500.0)
+
+		original, _ := sensor.LearnedRange()
+		cloned, _ := clone.LearnedRange()
+		assert.Equal(t, sensors.Range{Min: 5, Max: 5}, original)
+		assert.Equal(t, sensors.Range{Min: 5, Max: 500}, cloned)
+	})
+
+	t.Run("Parallel batches match sequential batches", func(t *testing.T) {
+		inputs := make([]interface{}, 40)
+		for i := range inputs {
+			inputs[i] = float64((i * 37) % 101)
+		}
+
+		params := map[string]interface{}{"warmup": 5}
+		sequential := sensors.EncodeBatch(newSensor(t, params), inputs, sensors.BatchOptions{})
+
+		sensor := newSensor(t, params)
+		assert.Equal(t, true, sensor.Metadata().Capabilities["stateful"])
+		parallel := sensors.EncodeBatch(sensor, inputs, sensors.BatchOptions{Workers: 4})
+		for i := range inputs {
+			require.NoError(t, parallel[i].Err)
+			assert.Equal(t, sequential[i].SDR.ActiveBits(), parallel[i].SDR.ActiveBits(), "Item %d", i)
+		}
+		assert.Equal(t, len(inputs), sensor.Metadata().Capabilities["samples"], "The original should have seen the whole batch")
+	})
+
+	t.Run("Invalid configuration is rejected", func(t *testing.T) {
+		invalid := []map[string]interface{}{
+			{"buckets": 1},
+			{"buckets": 5000},
+			{"warmup": -1},
+			{"rebucket": "sometimes"},
+			{"margin": -0.1},
+		}
+
+		for _, params := range invalid {
+			config := sensors.NewSensorConfig()
+			for key, value := range params {
+				config.SetParam(key, value)
+			}
+			assert.Error(t, encoders.NewAdaptiveNumericSensor().Configure(*config), "Params %v should be rejected", params)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package integration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDefinitions writes a definitions file into a temporary directory
+func writeDefinitions(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// TestSensorDefinitions validates declarative sensor definitions loaded from files
+func TestSensorDefinitions(t *testing.T) {
+	t.Run("YAML definitions merge templates and overrides", func(t *testing.T) {
+		path := writeDefinitions(t, "sensors.yaml", `
+sensors:
+  - name: temperature
+    type: numeric
+    template: small
+    resolution: 0.5
+    range: {min: -20, max: 50}
+    params:
+      silent_failure: false
+  - name: room
+    type: categorical
+    params:
+      categories: [kitchen, office, garage]
+      seed: 7
+`)
+
+		definitions, err := sensors.LoadDefinitions(path)
+		require.NoError(t, err)
+		require.Len(t, definitions, 2)
+
+		temperature := definitions[0]
+		assert.Equal(t, "temperature", temperature.Name)
+		assert.Equal(t, "small", temperature.Template)
+		assert.Equal(t, 3, temperature.Line)
+		assert.Equal(t, 1024, temperature.Config.SDRWidth)
+		assert.Equal(t, 0.5, temperature.Config.Resolution)
+		assert.Equal(t, &sensors.Range{Min: -20, Max: 50}, temperature.Config.Range)
+		assert.Equal(t, false, temperature.Config.GetBoolParam("silent_failure", true))
+
+		room := definitions[1]
+		assert.Equal(t, 2048, room.Config.SDRWidth, "Entries without a template start from the defaults")
+		assert.Equal(t, 7, room.Config.GetIntParam("seed", 0))
+
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+		instances, err := registry.CreateDefined(definitions)
+		require.NoError(t, err)
+		require.Len(t, instances, 2)
+
+		encoded, err := instances["room"].Encode("office")
+		require.NoError(t, err)
+		assert.NotEmpty(t, encoded.ActiveBits())
+
+		_, err = instances["temperature"].Encode(80.0)
+		assert.Error(t, err, "Out of range value should fail with silent failure disabled")
+	})
+
+	t.Run("JSON definitions are accepted", func(t *testing.T) {
+		path := writeDefinitions(t, "sensors.json", `{
+	"sensors": [
+		{"name": "level", "type": "numeric", "template": "sparse", "sdr_width": 4096}
+	]
+}`)
+
+		definitions, err := sensors.LoadDefinitions(path)
+		require.NoError(t, err)
+		require.Len(t, definitions, 1)
+		assert.Equal(t, 3, definitions[0].Line)
+		assert.Equal(t, 4096, definitions[0].Config.SDRWidth)
+		assert.Equal(t, 0.01, definitions[0].Config.TargetSparsity)
+	})
+
+	t.Run("Every invalid entry is reported with its line", func(t *testing.T) {
+		path := writeDefinitions(t, "broken.yaml", `sensors:
+  - name: ok
+    type: numeric
+  - name: too_dense
+    type: numeric
+    target_sparsity: 0.5
+  - type: text
+  - name: unknown_template
+    type: numeric
+    template: huge
+  - name: ok
+    type: categorical
+  - name: typo
+    type: numeric
+    sdr_widht: 1024
+  - name: bad_width
+    type: numeric
+    sdr_width: wide
+`)
+
+		definitions, err := sensors.LoadDefinitions(path)
+		require.Error(t, err)
+		require.Len(t, definitions, 1, "Valid entries should still be returned")
+		assert.Equal(t, "ok", definitions[0].Name)
+
+		var errs sensors.DefinitionErrors
+		require.True(t, errors.As(err, &errs))
+
+		lines := make([]int, len(errs))
+		for i, entry := range errs {
+			lines[i] = entry.Line
+			assert.Equal(t, path, entry.Source)
+		}
+		assert.Equal(t, []int{4, 7, 10, 11, 15, 18}, lines)
+		assert.Equal(t, "too_dense", errs[0].Sensor)
+		assert.Contains(t, errs[4].Error(), "unknown key 'sdr_widht'")
+		assert.Contains(t, err.Error(), "6 invalid sensor definition(s)")
+	})
+
+	t.Run("Creation failures are reported per definition", func(t *testing.T) {
+		path := writeDefinitions(t, "sensors.yaml", `sensors:
+  - name: missing_type
+    type: thermometer
+  - name: temperature
+    type: numeric
+  - name: bad_embedding
+    type: embedding
+`)
+
+		definitions, err := sensors.LoadDefinitions(path)
+		require.NoError(t, err)
+
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+		instances, err := registry.CreateDefined(definitions)
+		require.Error(t, err)
+		assert.Contains(t, instances, "temperature")
+
+		var errs sensors.DefinitionErrors
+		require.True(t, errors.As(err, &errs))
+		require.Len(t, errs, 2)
+		assert.Equal(t, 2, errs[0].Line)
+		assert.Equal(t, "bad_embedding", errs[1].Sensor)
+	})
+
+	t.Run("Malformed files fail with location", func(t *testing.T) {
+		_, err := sensors.ParseDefinitions([]byte("sensors: [\n  {name: a"), "inline.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "inline.yaml")
+
+		_, err = sensors.LoadDefinitions(filepath.Join(t.TempDir(), "missing.yaml"))
+		assert.Error(t, err)
+
+		definitions, err := sensors.ParseDefinitions(nil, "empty.yaml")
+		assert.NoError(t, err)
+		assert.Empty(t, definitions)
+	})
+}
@@ -0,0 +1,221 @@
+package integration
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blankBitmapPNG returns a blank 1-bit grayscale PNG of the given size, which
+// compresses to a tiny fraction of its decoded size
+func blankBitmapPNG(width, height int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+
+	writeChunk := func(kind string, data []byte) {
+		binary.Write(&buf, binary.BigEndian, uint32(len(data)))
+		chunk := append([]byte(kind), data...)
+		buf.Write(chunk)
+		binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(chunk))
+	}
+
+	header := make([]byte, 13)
+	binary.BigEndian.PutUint32(header[0:], uint32(width))
+	binary.BigEndian.PutUint32(header[4:], uint32(height))
+	header[8] = 1 // bit depth, color type 0 (grayscale)
+	writeChunk("IHDR", header)
+
+	var pixels bytes.Buffer
+	compressor := zlib.NewWriter(&pixels)
+	row := make([]byte, 1+(width+7)/8) // filter byte and packed pixels
+	for y := 0; y < height; y++ {
+		compressor.Write(row)
+	}
+	compressor.Close()
+	writeChunk("IDAT", pixels.Bytes())
+	writeChunk("IEND", nil)
+
+	return buf.Bytes()
+}
+
+// squareGrid returns a size×size grid with a filled square at the given offset
+func squareGrid(size, x0, y0, side int) [][]float64 {
+	grid := make([][]float64, size)
+	for y := range grid {
+		grid[y] = make([]float64, size)
+		for x := range grid[y] {
+			if x >= x0 && x < x0+side && y >= y0 && y < y0+side {
+				grid[y][x] = 1.0
+			}
+		}
+	}
+	return grid
+}
+
+// gridToImage converts a [0, 1] grid into a grayscale image
+func gridToImage(grid [][]float64) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, len(grid[0]), len(grid)))
+	for y, row := range grid {
+		for x, value := range row {
+			img.SetGray(x, y, color.Gray{Y: uint8(value * 255)})
+		}
+	}
+	return img
+}
+
+// TestSpatialPipeline validates spatial data encoding pipeline
+func TestSpatialPipeline(t *testing.T) {
+	t.Run("Grid, image and PNG inputs encode identically", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "spatial", config)
+
+		grid := squareGrid(90, 20, 20, 40)
+		img := gridToImage(grid)
+		var buffer bytes.Buffer
+		require.NoError(t, png.Encode(&buffer, img))
+
+		fromGrid, err := sensor.Encode(grid)
+		require.NoError(t, err)
+		fromImage, err := sensor.Encode(img)
+		require.NoError(t, err)
+		fromPNG, err := sensor.Encode(buffer.Bytes())
+		require.NoError(t, err)
+
+		assert.Len(t, fromGrid.ActiveBits(), config.CalculateActiveBitsCount())
+		assert.Equal(t, fromGrid.ActiveBits(), fromImage.ActiveBits())
+		assert.Equal(t, fromGrid.ActiveBits(), fromPNG.ActiveBits())
+	})
+
+	t.Run("Topology preservation", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "spatial", config)
+
+		base, _ := sensor.Encode(squareGrid(90, 10, 10, 8))
+		shifted, _ := sensor.Encode(squareGrid(90, 12, 10, 8))
+		distant, _ := sensor.Encode(squareGrid(90, 70, 70, 8))
+
+		assert.Greater(t, base.Similarity(shifted), 0.5, "Slightly shifted shapes should overlap")
+		assert.Equal(t, 0.0, base.Similarity(distant), "Distant shapes should not overlap")
+	})
+
+	t.Run("Pixel noise tolerance", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "spatial", config)
+		rng := rand.New(rand.NewSource(1))
+
+		// Cells of 4x4 pixels give the majority vote enough samples to absorb noise
+		clean := squareGrid(180, 40, 40, 80)
+		cleanSDR, err := sensor.Encode(clean)
+		require.NoError(t, err)
+
+		for trial := 0; trial < 10; trial++ {
+			noisy := squareGrid(180, 40, 40, 80)
+			for y := range noisy {
+				for x := range noisy[y] {
+					if rng.Float64() < 0.15 {
+						noisy[y][x] = 1.0 - noisy[y][x]
+					}
+				}
+			}
+
+			noisySDR, err := sensor.Encode(noisy)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, cleanSDR.Similarity(noisySDR), 0.9, "15% pixel noise should keep ≥90% of active bits")
+		}
+	})
+
+	t.Run("Local contrast binarization", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SetParam("binarization", "local_contrast")
+		config.SetParam("contrast_radius", 3)
+		sensor := newPipelineSensor(t, "spatial", config)
+
+		// A bright square on a gradient background fails a global threshold
+		grid := squareGrid(90, 30, 30, 20)
+		for y := range grid {
+			for x := range grid[y] {
+				grid[y][x] = grid[y][x]*0.3 + float64(x)/180.0
+			}
+		}
+
+		sdr, err := sensor.Encode(grid)
+		require.NoError(t, err)
+		assert.NotEmpty(t, sdr.ActiveBits())
+	})
+
+	t.Run("Tile subdivision", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 1024
+		config.SetParam("tiles_x", 2)
+		config.SetParam("tiles_y", 2)
+		sensor := newPipelineSensor(t, "spatial", config)
+
+		tiler, ok := sensor.(*encoders.SpatialSensor)
+		require.True(t, ok)
+
+		tiles, err := tiler.EncodeTiles(squareGrid(64, 0, 0, 32))
+		require.NoError(t, err)
+		require.Len(t, tiles, 4, "2x2 tiling should produce four SDRs")
+
+		assert.NotEmpty(t, tiles[0].ActiveBits(), "Top-left tile contains the square")
+		for _, tile := range tiles[1:] {
+			assert.Empty(t, tile.ActiveBits(), "Other tiles are blank")
+		}
+
+		for _, input := range []interface{}{squareGrid(1, 0, 0, 1), "text"} {
+			tiles, err := tiler.EncodeTiles(input)
+			require.NoError(t, err)
+			require.Len(t, tiles, 4, "Failed input should still produce one SDR per tile")
+			for _, tile := range tiles {
+				assert.Empty(t, tile.ActiveBits())
+			}
+		}
+	})
+
+	t.Run("Oversized PNG is rejected before decoding", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "spatial", config)
+
+		// 8000×8000 pixels compress to a few KB but decode to 64MB
+		oversized := blankBitmapPNG(8000, 8000)
+		require.Less(t, len(oversized), 1024*1024)
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		sdr, err := sensor.Encode(oversized)
+		runtime.ReadMemStats(&after)
+
+		require.NoError(t, err)
+		assert.Empty(t, sdr.ActiveBits())
+		assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1024*1024),
+			"Image dimensions should be checked before the pixels are decoded")
+	})
+
+	t.Run("Invalid inputs trigger silent failure", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "spatial", config)
+
+		for _, input := range []interface{}{
+			[][]float64{},
+			[][]float64{{1, 2}, {3}},
+			[]byte("not a png"),
+			"text",
+		} {
+			sdr, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, sdr.ActiveBits())
+		}
+	})
+}
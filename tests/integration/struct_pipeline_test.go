@@ -0,0 +1,141 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// machineReading is a tagged struct used by the struct pipeline tests
+type machineReading struct {
+	Load   float64 `htm:"numeric,min=0,max=100,resolution=0.5,width=1024"`
+	Mode   string  `htm:"category,categories=idle|run|fault,width=512,sparsity=0.04"`
+	Serial string  `htm:"-"`
+	note   string
+}
+
+// newStructPipelineSensor creates a struct sensor for machineReading and configures it
+func newStructPipelineSensor(t *testing.T) *encoders.StructSensor {
+	t.Helper()
+
+	registry := sensors.NewRegistry()
+	require.NoError(t, registry.Register("numeric", encoders.NewNumericSensor))
+	require.NoError(t, registry.Register("categorical", encoders.NewCategoricalSensor))
+
+	sensor := encoders.NewStructSensor(registry, machineReading{})
+	require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()), "Struct sensor configuration should succeed")
+
+	return sensor
+}
+
+// TestStructPipeline validates tag-driven encoding of Go structs
+func TestStructPipeline(t *testing.T) {
+	t.Run("Tagged fields are encoded in order", func(t *testing.T) {
+		sensor := newStructPipelineSensor(t)
+
+		assert.Equal(t, []encoders.CompositeSegment{
+			{Name: "Load", Type: "numeric", Offset: 0, Width: 1024},
+			{Name: "Mode", Type: "categorical", Offset: 1024, Width: 512},
+		}, sensor.Layout())
+
+		metadata := sensor.Metadata()
+		assert.Equal(t, "struct", metadata.Type)
+		assert.Equal(t, 1536, metadata.SDRWidth)
+
+		sdr, err := sensor.Encode(machineReading{Load: 42, Mode: "run", Serial: "A1", note: "x"})
+		require.NoError(t, err)
+		assert.Len(t, sdr.ActiveBits(), 40)
+
+		fromPointer, err := sensor.Encode(&machineReading{Load: 42, Mode: "run", Serial: "B2"})
+		require.NoError(t, err)
+		assert.Equal(t, sdr.ActiveBits(), fromPointer.ActiveBits(), "Untagged fields should not affect the encoding")
+	})
+
+	t.Run("Similar structs overlap", func(t *testing.T) {
+		sensor := newStructPipelineSensor(t)
+
+		base, _ := sensor.Encode(machineReading{Load: 40, Mode: "run"})
+		near, _ := sensor.Encode(machineReading{Load: 41, Mode: "run"})
+		other, _ := sensor.Encode(machineReading{Load: 90, Mode: "fault"})
+
+		assert.Greater(t, base.Similarity(near), 0.7)
+		assert.Less(t, base.Similarity(other), 0.1)
+	})
+
+	t.Run("Clone encodes identically", func(t *testing.T) {
+		sensor := newStructPipelineSensor(t)
+		clone := sensor.Clone()
+
+		reading := machineReading{Load: 12.5, Mode: "idle"}
+		original, err := sensor.Encode(reading)
+		require.NoError(t, err)
+		cloned, err := clone.Encode(reading)
+		require.NoError(t, err)
+		assert.Equal(t, original.ActiveBits(), cloned.ActiveBits())
+	})
+
+	t.Run("Tag options follow the child parameter types", func(t *testing.T) {
+		// A single category is still a list and a numeric separator still a string
+		type singleValues struct {
+			Color string `htm:"category,categories=red,width=512"`
+			Path  string `htm:"hierarchical,separator=1,depth=2"`
+		}
+
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+
+		sensor := encoders.NewStructSensor(registry, singleValues{})
+		require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+
+		sdr, err := sensor.Encode(singleValues{Color: "red", Path: "a1b"})
+		require.NoError(t, err)
+		assert.NotEmpty(t, sdr.ActiveBits())
+
+		unknown, err := sensor.Encode(singleValues{Color: "blue", Path: "a1b"})
+		require.NoError(t, err)
+		assert.Empty(t, unknown.ActiveBits(), "Categories outside the one-element vocabulary should fail")
+	})
+
+	t.Run("Invalid tags are rejected", func(t *testing.T) {
+		type untagged struct{ Value float64 }
+		type badOption struct {
+			Value float64 `htm:"numeric,min=low"`
+		}
+		type unknownType struct {
+			Value float64 `htm:"thermometer"`
+		}
+		type badTypedOption struct {
+			Value float64 `htm:"numeric,clip_input=sometimes"`
+		}
+		type unknownOption struct {
+			Value float64 `htm:"numeric,label=outdoor"`
+		}
+
+		registry := sensors.NewRegistry()
+		require.NoError(t, registry.Register("numeric", encoders.NewNumericSensor))
+
+		for _, sample := range []interface{}{untagged{}, badOption{}, unknownType{}, badTypedOption{}, unknownOption{}, 42, nil} {
+			sensor := encoders.NewStructSensor(registry, sample)
+			assert.Error(t, sensor.Configure(*sensors.NewSensorConfig()), "Sample %T should be rejected", sample)
+		}
+	})
+
+	t.Run("Invalid inputs trigger silent failure", func(t *testing.T) {
+		sensor := newStructPipelineSensor(t)
+
+		for _, input := range []interface{}{
+			machineReading{Load: 150, Mode: "run"},
+			machineReading{Load: 10, Mode: "unknown"},
+			map[string]interface{}{"Load": 10.0, "Mode": "run"},
+			(*machineReading)(nil),
+			"reading",
+		} {
+			sdr, err := sensor.Encode(input)
+			require.NoError(t, err)
+			assert.Empty(t, sdr.ActiveBits(), "Input %v should produce an empty SDR", input)
+		}
+	})
+}
@@ -1,11 +1,29 @@
 package integration
 
 import (
+	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/require"
 )
 
+// newPipelineSensor creates a built-in sensor through the default registry and configures it
+func newPipelineSensor(t *testing.T, sensorType string, config *sensors.SensorConfig) sensors.SensorInterface {
+	t.Helper()
+
+	registry, err := encoders.NewDefaultRegistry()
+	require.NoError(t, err)
+
+	sensor, err := registry.Create(sensorType)
+	require.NoError(t, err)
+	require.NoError(t, sensor.Configure(*config), "%s sensor configuration should succeed", sensorType)
+
+	return sensor
+}
+
 // generateTestMatrix creates a test matrix with specified dimensions
 func generateTestMatrix(rows, cols int) [][]float64 {
 	matrix := make([][]float64, rows)
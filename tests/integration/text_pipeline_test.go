@@ -1,40 +1,150 @@
 package integration
 
 import (
+	"math/rand"
+	"strings"
 	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// addCharacterNoise replaces the given fraction of characters with random letters
+func addCharacterNoise(rng *rand.Rand, text string, fraction float64) string {
+	runes := []rune(text)
+	errors := int(float64(len(runes)) * fraction)
+	for i := 0; i < errors; i++ {
+		runes[rng.Intn(len(runes))] = rune('a' + rng.Intn(26))
+	}
+	return string(runes)
+}
+
 // TestTextPipeline validates text data encoding pipeline
 func TestTextPipeline(t *testing.T) {
 	t.Run("Basic text encoding pipeline", func(t *testing.T) {
-		// This will fail until text pipeline is implemented
-		t.Skip("Text pipeline not implemented yet - this test must fail first")
-
-		// Future implementation test based on quickstart.md:
-		// registry := sensors.NewRegistry()
-		// registry.Register("text", encoders.NewTextSensor)
-		//
-		// sensor, err := registry.Create("text")
-		// require.NoError(t, err)
-		//
-		// // Configure text sensor
-		// config := sensors.NewConfig()
-		// config.SetParam("sdr_width", 4096)
-		// config.SetParam("target_sparsity", 0.02)
-		//
-		// err = sensor.Configure(config)
-		// require.NoError(t, err, "Text sensor configuration should succeed")
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 4096
+		sensor := newPipelineSensor(t, "text", config)
+
+		texts := []string{
+			"short",
+			"medium length text with some words",
+			"much longer text that contains multiple sentences. And various words to test encoding!",
+		}
+		for _, text := range texts {
+			sdr, err := sensor.Encode(text)
+			require.NoError(t, err)
+
+			assert.Equal(t, 4096, sdr.Width())
+			assert.Len(t, sdr.ActiveBits(), config.CalculateActiveBitsCount(),
+				"Sparsity should be normalized to target for %q", text)
+
+			again, _ := sensor.Encode(text)
+			assert.Equal(t, sdr.ActiveBits(), again.ActiveBits(), "Same text should produce identical SDR")
+		}
+	})
+
+	t.Run("Character noise tolerance", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "text", config)
+		rng := rand.New(rand.NewSource(1))
+
+		texts := []string{
+			"The quick brown fox jumps over the lazy dog while the farmer watches from the old wooden porch near the river bank",
+			"Hierarchical temporal memory models learn sequences of sparse distributed representations from streaming sensor data",
+		}
+
+		// With 40 active bits one bit is 2.5% of the encoding, so single
+		// trials swing around the mean; FR-015 is checked on the average
+		total, trials := 0.0, 0
+		for _, text := range texts {
+			clean, err := sensor.Encode(text)
+			require.NoError(t, err)
+
+			for i := 0; i < 100; i++ {
+				noisy, err := sensor.Encode(addCharacterNoise(rng, text, 0.05))
+				require.NoError(t, err)
+
+				similarity := clean.Similarity(noisy)
+				assert.GreaterOrEqual(t, similarity, 0.85, "5%% character errors should keep most active bits: %.2f", similarity)
+				total += similarity
+				trials++
+			}
+		}
+
+		assert.GreaterOrEqual(t, total/float64(trials), 0.9, "FR-015: at least 90% stability under 5% character errors")
+	})
+
+	t.Run("Anagrams and reordered words stay apart", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "text", config)
+
+		for _, pair := range [][2]string{
+			{"listen", "silent"},
+			{"dog", "god"},
+			{"temperature sensor offline", "network switch rebooted"},
+		} {
+			first, _ := sensor.Encode(pair[0])
+			second, _ := sensor.Encode(pair[1])
+			assert.LessOrEqual(t, first.Similarity(second), 0.3, "%q and %q should barely overlap", pair[0], pair[1])
+		}
+
+		ordered, _ := sensor.Encode("error in payment service")
+		reordered, _ := sensor.Encode("payment service error in")
+		assert.NotEqual(t, ordered.ActiveBits(), reordered.ActiveBits(), "Word order should change the encoding")
+	})
+
+	t.Run("Similar texts overlap more than unrelated texts", func(t *testing.T) {
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "text", config)
+
+		base, _ := sensor.Encode("database connection timeout after thirty seconds")
+		similar, _ := sensor.Encode("database connection timeout after sixty seconds")
+		unrelated, _ := sensor.Encode("please bring an umbrella tomorrow morning")
+
+		assert.Greater(t, base.Similarity(similar), base.Similarity(unrelated))
 	})
 
 	t.Run("Document size handling", func(t *testing.T) {
-		t.Skip("Text pipeline not implemented yet - this test must fail first")
+		config := sensors.NewSensorConfig()
+		config.SDRWidth = 8192
+		sensor := newPipelineSensor(t, "text", config)
+
+		atLimit := strings.Repeat("word ", 1024*1024/5)
+		sdr, err := sensor.Encode(atLimit)
+		require.NoError(t, err)
+		assert.Len(t, sdr.ActiveBits(), config.CalculateActiveBitsCount(), "Documents up to 1MB should encode")
 
-		// Future implementation: Test 1MB document limit and handling
+		oversized := strings.Repeat("x", 1024*1024+1)
+		sdr, err = sensor.Encode(oversized)
+		require.NoError(t, err, "Oversized document should trigger silent failure")
+		assert.Empty(t, sdr.ActiveBits())
+
+		strictConfig := sensors.NewSensorConfig()
+		strictConfig.SetParam("silent_failure", false)
+		strict := newPipelineSensor(t, "text", strictConfig)
+		_, err = strict.Encode(oversized)
+		assert.Error(t, err, "Oversized document should fail outside silent failure mode")
 	})
 
 	t.Run("Unicode text support", func(t *testing.T) {
-		t.Skip("Text pipeline not implemented yet - this test must fail first")
+		config := sensors.NewSensorConfig()
+		sensor := newPipelineSensor(t, "text", config)
+
+		texts := []string{"Français élégant", "Русский текст", "日本語のテキスト", "العربية", "हिन्दी पाठ"}
+		for _, text := range texts {
+			sdr, err := sensor.Encode(text)
+			require.NoError(t, err)
+			assert.Len(t, sdr.ActiveBits(), config.CalculateActiveBitsCount(), "Unicode text %q should encode", text)
+		}
+
+		lower, _ := sensor.Encode("ÉCOLE")
+		upper, _ := sensor.Encode("école")
+		assert.Equal(t, lower.ActiveBits(), upper.ActiveBits(), "Encoding should be case insensitive by default")
 
-		// Future implementation: Test multi-language Unicode text encoding
+		empty, err := sensor.Encode("  ...  ")
+		require.NoError(t, err)
+		assert.Empty(t, empty.ActiveBits(), "Text without tokens should produce empty SDR")
 	})
 }
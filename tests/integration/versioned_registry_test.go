@@ -0,0 +1,168 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/htm-project/neural-api/internal/sensors"
+	"github.com/htm-project/neural-api/internal/sensors/encoders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVersionedRegistry validates side-by-side sensor versions and version pinning
+func TestVersionedRegistry(t *testing.T) {
+	// The numeric and RDSE encoders stand in for two generations of one algorithm
+	newVersionedRegistry := func(t *testing.T) *sensors.Registry {
+		registry := sensors.NewRegistry()
+		require.NoError(t, registry.RegisterVersion("numeric", "2.0.0", encoders.NewRDSESensor))
+		require.NoError(t, registry.RegisterVersion("numeric", "1.0.0", encoders.NewNumericSensor))
+		require.NoError(t, registry.RegisterVersion("numeric", "1.2.0", encoders.NewNumericSensor))
+		return registry
+	}
+
+	t.Run("Keys resolve to the newest matching version", func(t *testing.T) {
+		registry := newVersionedRegistry(t)
+
+		assert.Equal(t, []string{"1.0.0", "1.2.0", "2.0.0"}, registry.Versions("numeric"))
+		assert.Equal(t, []string{"numeric"}, registry.List())
+		assert.Equal(t, 1, registry.Count())
+
+		resolved := map[string]string{
+			"numeric":        "numeric@2.0.0",
+			"numeric@latest": "numeric@2.0.0",
+			"numeric@1":      "numeric@1.2.0",
+			"numeric@1.0":    "numeric@1.0.0",
+			"numeric@1.2.0":  "numeric@1.2.0",
+		}
+		for key, expected := range resolved {
+			actual, err := registry.Resolve(key)
+			require.NoError(t, err, "%s should resolve", key)
+			assert.Equal(t, expected, actual, "%s should resolve to %s", key, expected)
+		}
+
+		latest, err := registry.Create("numeric")
+		require.NoError(t, err)
+		assert.Equal(t, "rdse", latest.Metadata().Type)
+
+		pinned, err := registry.Create("numeric@1")
+		require.NoError(t, err)
+		assert.Equal(t, "numeric", pinned.Metadata().Type)
+
+		version, tagged := registry.Version("numeric@1")
+		assert.True(t, tagged)
+		assert.Equal(t, "1.2.0", version)
+
+		info := registry.GetInfo()
+		assert.Equal(t, "2.0.0", info.Versions["numeric"])
+		assert.Equal(t, []string{"1.0.0", "1.2.0", "2.0.0"}, info.AllVersions["numeric"])
+	})
+
+	t.Run("Pinned keys keep encoding identically after upgrades", func(t *testing.T) {
+		registry := sensors.NewRegistry()
+		require.NoError(t, registry.RegisterVersion("numeric", "1.0.0", encoders.NewNumericSensor))
+
+		pinned, err := registry.Resolve("numeric")
+		require.NoError(t, err)
+		assert.Equal(t, "numeric@1.0.0", pinned)
+
+		encode := func(key string) []int {
+			sensor, err := registry.Create(key)
+			require.NoError(t, err)
+			require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+
+			encoded, err := sensor.Encode(42.0)
+			require.NoError(t, err)
+			return encoded.ActiveBits()
+		}
+		before := encode(pinned)
+
+		require.NoError(t, registry.RegisterVersion("numeric", "2.0.0", encoders.NewRDSESensor))
+		assert.Equal(t, before, encode(pinned), "Pinned key should keep the original algorithm")
+		assert.NotEqual(t, before, encode("numeric"), "Bare key should move to the new algorithm")
+	})
+
+	t.Run("Sensors report the version they were created under", func(t *testing.T) {
+		registry := newVersionedRegistry(t)
+
+		// 1.0.0 and 1.2.0 share a factory, so only the registry knows the version
+		describe := func(key string) sensors.SensorMetadata {
+			sensor, err := registry.Create(key)
+			require.NoError(t, err)
+			require.NoError(t, sensor.Configure(*sensors.NewSensorConfig()))
+			return sensor.Metadata()
+		}
+		first, second := describe("numeric@1.0.0"), describe("numeric@1.2.0")
+
+		assert.Equal(t, "1.0.0", first.Version)
+		assert.Equal(t, "1.2.0", second.Version)
+		assert.NotEqual(t, first.Fingerprint, second.Fingerprint, "Versions should fingerprint apart")
+		assert.Equal(t, "2.0.0", describe("numeric").Version)
+	})
+
+	t.Run("Invalid registrations and keys are rejected", func(t *testing.T) {
+		registry := newVersionedRegistry(t)
+
+		assert.Error(t, registry.RegisterVersion("numeric", "1.2.0", encoders.NewNumericSensor), "Duplicate version")
+		assert.Error(t, registry.RegisterVersion("numeric", "3.0", encoders.NewNumericSensor), "Incomplete version")
+		assert.Error(t, registry.RegisterVersion("numeric", "v3.0.0", encoders.NewNumericSensor), "Non-numeric version")
+		assert.Error(t, registry.Register("numeric", encoders.NewNumericSensor), "Unversioned registration of a versioned type")
+		assert.Error(t, registry.Register("text@1", encoders.NewTextSensor), "Version separator in type")
+
+		require.NoError(t, registry.Register("text", encoders.NewTextSensor))
+		assert.Error(t, registry.RegisterVersion("text", "1.0.0", encoders.NewTextSensor), "Versioned registration of an unversioned type")
+
+		for _, key := range []string{"numeric@3", "numeric@1.x", "numeric@", "text@1", "thermometer@1"} {
+			_, err := registry.Create(key)
+			assert.Error(t, err, "%s should not resolve", key)
+			assert.False(t, registry.IsRegistered(key))
+		}
+
+		_, err := registry.Create("text@latest")
+		assert.NoError(t, err)
+		_, tagged := registry.Version("text")
+		assert.False(t, tagged)
+	})
+
+	t.Run("Single versions can be unregistered", func(t *testing.T) {
+		registry := newVersionedRegistry(t)
+
+		assert.Error(t, registry.Unregister("numeric@2"), "Partial pins should not unregister")
+		assert.Error(t, registry.Unregister("numeric@3.0.0"))
+
+		require.NoError(t, registry.Unregister("numeric@2.0.0"))
+		version, _ := registry.Version("numeric")
+		assert.Equal(t, "1.2.0", version)
+
+		require.NoError(t, registry.Unregister("numeric"))
+		assert.False(t, registry.IsRegistered("numeric@1.0.0"))
+		assert.Nil(t, registry.Versions("numeric"))
+	})
+
+	t.Run("Definitions and composites accept pinned keys", func(t *testing.T) {
+		registry, err := encoders.NewDefaultRegistry()
+		require.NoError(t, err)
+
+		definitions, err := sensors.ParseDefinitions([]byte(`sensors:
+  - name: temperature
+    type: numeric@1
+`), "pinned.yaml")
+		require.NoError(t, err)
+
+		instances, err := registry.CreateDefined(definitions)
+		require.NoError(t, err)
+		assert.Equal(t, "numeric", instances["temperature"].Metadata().Type)
+
+		composite := encoders.NewCompositeSensor(registry,
+			encoders.CompositeField{Name: "value", Type: "numeric@" + encoders.BuiltinVersion, Config: *smallNumericConfig()},
+		)
+		require.NoError(t, composite.Configure(*sensors.NewSensorConfig()))
+	})
+}
+
+// smallNumericConfig returns a numeric child configuration with few buckets
+func smallNumericConfig() *sensors.SensorConfig {
+	config := sensors.NewSensorConfig()
+	config.SDRWidth = 512
+	config.Resolution = 1
+	return config
+}